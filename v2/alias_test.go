@@ -0,0 +1,70 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v2"
+	"github.com/simia-tech/env/v2/envtest"
+)
+
+func TestAliases(t *testing.T) {
+	env.Clear()
+	field := env.String("ALIAS_NEW", "default", env.Aliases("ALIAS_OLD", "ALIAS_OLDER"))
+	deprecated := env.String("ALIAS_DEPRECATED", "default", env.Deprecated("use ALIAS_NEW"))
+
+	warnings := []error{}
+	wh := env.WarningHandler
+	env.WarningHandler = func(err error) {
+		warnings = append(warnings, err)
+	}
+	defer func() {
+		env.WarningHandler = wh
+	}()
+
+	testFn := func(field *env.StringField, values map[string]string, expectValue string, expectErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			envtest.WithValues(t, values)
+
+			value, err := field.Get()
+			if expectErr == nil {
+				require.NoError(t, err)
+				assert.Equal(t, expectValue, value)
+			} else {
+				assert.ErrorIs(t, err, expectErr)
+			}
+		}
+	}
+
+	t.Run("New", testFn(field, map[string]string{"ALIAS_NEW": "new"}, "new", nil))
+	t.Run("Old", testFn(field, map[string]string{"ALIAS_OLD": "old"}, "old", nil))
+	t.Run("Older", testFn(field, map[string]string{"ALIAS_OLDER": "older"}, "older", nil))
+	t.Run("Same", testFn(field, map[string]string{"ALIAS_NEW": "new", "ALIAS_OLD": "new"}, "new", nil))
+	t.Run("Conflict", testFn(field, map[string]string{"ALIAS_NEW": "new", "ALIAS_OLD": "old"}, "", env.ErrConflictingValues))
+	t.Run("Deprecated", testFn(deprecated, map[string]string{"ALIAS_DEPRECATED": "value"}, "value", nil))
+	t.Run("DeprecatedAgain", testFn(deprecated, map[string]string{"ALIAS_DEPRECATED": "value"}, "value", nil))
+
+	require.Len(t, warnings, 3)
+	assert.EqualError(t, warnings[0], "field ALIAS_NEW: name ALIAS_OLD: deprecated")
+	assert.EqualError(t, warnings[1], "field ALIAS_NEW: name ALIAS_OLDER: deprecated")
+	assert.EqualError(t, warnings[2], "field ALIAS_DEPRECATED: deprecated: use ALIAS_NEW")
+
+	assert.Regexp(t, `^String field. Deprecated names are 'ALIAS_OLD' and 'ALIAS_OLDER'. `, field.Description())
+	assert.Regexp(t, `^String field. Deprecated: use ALIAS_NEW. `, deprecated.Description())
+}
@@ -28,13 +28,19 @@ type BoolField struct {
 // Bool registers a field of the provided name.
 func Bool(name string, defaultValue bool, opts ...Option) *BoolField {
 	field := &BoolField{
-		field:        newField("Boolean", name, append(opts, AllowedValues("0", "1", falseValue, trueValue, "no", "yes", ""))),
+		field:        newField("Boolean", name, append(opts, AllowedValues("0", "1", falseValue, trueValue, "no", "yes", ""), isBool())),
 		defaultValue: defaultValue,
 	}
 	RegisterField(field)
 	return field
 }
 
+func isBool() Option {
+	return func(o *options) {
+		o.isBool = true
+	}
+}
+
 // Value returns the field's value.
 func (f *BoolField) Value() string {
 	if f.GetOrDefault() {
@@ -68,7 +74,31 @@ func (f *BoolField) GetOrDefault() bool {
 
 // Get returns the field value or an error.
 func (f *BoolField) Get() (bool, error) {
-	v, err := f.value()
+	if value, ok := resolvedValue(f); ok {
+		return value.(bool), nil
+	}
+	return f.get(currentLookup())
+}
+
+func (f *BoolField) validate(lookup lookupFunc) error {
+	_, err := f.get(lookup)
+	return err
+}
+
+func (f *BoolField) resolve(lookup lookupFunc) (interface{}, error) {
+	return f.get(lookup)
+}
+
+func (f *BoolField) text(lookup lookupFunc) (string, error) {
+	value, err := f.get(lookup)
+	if value {
+		return trueValue, err
+	}
+	return falseValue, err
+}
+
+func (f *BoolField) get(lookup lookupFunc) (bool, error) {
+	v, err := f.value(lookup)
 	if err != nil {
 		return f.defaultValue, err
 	}
@@ -16,7 +16,6 @@ package env
 
 import (
 	"encoding/hex"
-	"fmt"
 )
 
 // BytesField implements a string field.
@@ -62,7 +61,28 @@ func (f *BytesField) GetOrDefault() []byte {
 
 // Get returns the field value or an error.
 func (f *BytesField) Get() ([]byte, error) {
-	v, err := f.value()
+	if value, ok := resolvedValue(f); ok {
+		return value.([]byte), nil
+	}
+	return f.get(currentLookup())
+}
+
+func (f *BytesField) validate(lookup lookupFunc) error {
+	_, err := f.get(lookup)
+	return err
+}
+
+func (f *BytesField) resolve(lookup lookupFunc) (interface{}, error) {
+	return f.get(lookup)
+}
+
+func (f *BytesField) text(lookup lookupFunc) (string, error) {
+	value, err := f.get(lookup)
+	return hex.EncodeToString(value), err
+}
+
+func (f *BytesField) get(lookup lookupFunc) ([]byte, error) {
+	v, err := f.value(lookup)
 	if err != nil {
 		return f.defaultValue, err
 	}
@@ -71,7 +91,7 @@ func (f *BytesField) Get() ([]byte, error) {
 	}
 	value, err := hex.DecodeString(v)
 	if err != nil {
-		return f.defaultValue, fmt.Errorf("field %s hex decode [%s]: %w", f.name, v, err)
+		return f.defaultValue, f.newError(v, err)
 	}
 	return value, nil
 }
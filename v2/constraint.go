@@ -0,0 +1,186 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Predicate defines a condition that is evaluated against the values of a snapshot.
+type Predicate func(*Snapshot) bool
+
+type constraint interface {
+	check(*Snapshot) error
+	sentence(name string) string
+}
+
+var constraints = []constraint{}
+
+// RequireTogether registers a constraint, that either all or none of the provided fields must be set.
+func RequireTogether(fields ...Field) {
+	constraints = append(constraints, requireTogether(fieldNames(fields)))
+}
+
+// MutuallyExclusive registers a constraint, that at most one of the provided fields must be set.
+func MutuallyExclusive(fields ...Field) {
+	constraints = append(constraints, mutuallyExclusive(fieldNames(fields)))
+}
+
+// RequiredIf registers a constraint, that the provided field must be set if the predicate is true.
+func RequiredIf(field Field, predicate Predicate) {
+	constraints = append(constraints, requiredIf{name: field.Name(), predicate: predicate})
+}
+
+// Validate validates the values of all registered fields and checks all registered constraints. The first
+// violation is returned as an error.
+func Validate() error {
+	return validate(&Snapshot{lookup: currentLookup()})
+}
+
+func validate(s *Snapshot) error {
+	names := Fields()
+	sort.Strings(names)
+
+	for _, name := range names {
+		if v, ok := fields[name].(validator); ok {
+			if err := v.validate(s.Value); err != nil {
+				return err
+			}
+		}
+	}
+	for _, c := range constraints {
+		if err := c.check(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func constraintSentences(name string) []string {
+	sentences := []string{}
+	for _, c := range constraints {
+		if sentence := c.sentence(name); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+	}
+	return sentences
+}
+
+type requireTogether []string
+
+func (c requireTogether) check(s *Snapshot) error {
+	set, unset := s.partition(c)
+	if len(set) == 0 || len(unset) == 0 {
+		return nil
+	}
+	return fmt.Errorf("fields %s must be set together: field %s: %w", joinStringValues(c), unset[0], ErrRequiredValueIsMissing)
+}
+
+func (c requireTogether) sentence(name string) string {
+	if others := otherNames(c, name); others != nil {
+		return fmt.Sprintf("Must be set together with %s.", joinStringValues(others))
+	}
+	return ""
+}
+
+type mutuallyExclusive []string
+
+func (c mutuallyExclusive) check(s *Snapshot) error {
+	set, _ := s.partition(c)
+	if len(set) < 2 {
+		return nil
+	}
+	return fmt.Errorf("fields %s: %w", joinStringValues(set), ErrMutuallyExclusive)
+}
+
+func (c mutuallyExclusive) sentence(name string) string {
+	if others := otherNames(c, name); others != nil {
+		return fmt.Sprintf("Must not be set together with %s.", joinStringValues(others))
+	}
+	return ""
+}
+
+type requiredIf struct {
+	name      string
+	predicate Predicate
+}
+
+func (c requiredIf) check(s *Snapshot) error {
+	if !c.predicate(s) {
+		return nil
+	}
+	if set, _ := s.partition([]string{c.name}); len(set) == 0 {
+		return fmt.Errorf("field %s is required by condition: %w", c.name, ErrRequiredValueIsMissing)
+	}
+	return nil
+}
+
+func (c requiredIf) sentence(name string) string {
+	if name == c.name {
+		return "Required under a condition."
+	}
+	return ""
+}
+
+func (s *Snapshot) partition(names []string) ([]string, []string) {
+	set, unset := []string{}, []string{}
+	for _, name := range names {
+		if s.isSet(name) {
+			set = append(set, name)
+		} else {
+			unset = append(unset, name)
+		}
+	}
+	return set, unset
+}
+
+func (s *Snapshot) isSet(name string) bool {
+	if s.Value(name) != "" {
+		return true
+	}
+	if field, ok := fields[name]; ok {
+		for _, alias := range fieldOptions(field).aliases {
+			if s.Value(alias) != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func fieldNames(fields []Field) []string {
+	names := make([]string, len(fields))
+	for index, field := range fields {
+		names[index] = field.Name()
+	}
+	return names
+}
+
+func otherNames(names []string, name string) []string {
+	others := []string{}
+	found := false
+	for _, n := range names {
+		if n == name {
+			found = true
+		} else {
+			others = append(others, n)
+		}
+	}
+	if !found || len(others) == 0 {
+		return nil
+	}
+	return others
+}
@@ -15,7 +15,6 @@
 package env
 
 import (
-	"fmt"
 	"time"
 )
 
@@ -62,7 +61,28 @@ func (f *DurationField) GetOrDefault() time.Duration {
 
 // Get returns the field value or an error.
 func (f *DurationField) Get() (time.Duration, error) {
-	v, err := f.value()
+	if value, ok := resolvedValue(f); ok {
+		return value.(time.Duration), nil
+	}
+	return f.get(currentLookup())
+}
+
+func (f *DurationField) validate(lookup lookupFunc) error {
+	_, err := f.get(lookup)
+	return err
+}
+
+func (f *DurationField) resolve(lookup lookupFunc) (interface{}, error) {
+	return f.get(lookup)
+}
+
+func (f *DurationField) text(lookup lookupFunc) (string, error) {
+	value, err := f.get(lookup)
+	return value.String(), err
+}
+
+func (f *DurationField) get(lookup lookupFunc) (time.Duration, error) {
+	v, err := f.value(lookup)
 	if err != nil {
 		return f.defaultValue, err
 	}
@@ -71,7 +91,7 @@ func (f *DurationField) Get() (time.Duration, error) {
 	}
 	value, err := time.ParseDuration(v)
 	if err != nil {
-		return f.defaultValue, fmt.Errorf("field %s parse duration [%s]: %w", f.name, v, err)
+		return f.defaultValue, f.newError(v, err)
 	}
 	return value, nil
 }
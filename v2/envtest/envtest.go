@@ -0,0 +1,47 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package envtest provides helpers to override environment fields in tests without modifying the environment of
+// the process.
+//
+// Overrides are global to the process and not scoped to a test: while a test overrides a field, every other test
+// that reads the field sees the overridden value. Tests that call t.Parallel must therefore neither override nor
+// read fields that are overridden by another parallel test. Unlike t.Setenv, the helpers don't prevent t.Parallel,
+// so parallel tests that use disjoint sets of fields are possible. Overrides stack, so a subtest can override a
+// field that is already overridden by its parent. For overrides that are scoped to a test, see the envtest package
+// of version 3.
+package envtest
+
+import (
+	"testing"
+
+	"github.com/simia-tech/env/v2"
+	"github.com/simia-tech/env/v2/internal/override"
+)
+
+// Override sets the value of the provided field for the whole process until the test and all its subtests have
+// completed. Afterwards, the previous override of the field (if any) is in effect again.
+func Override(t testing.TB, field env.Field, value string) {
+	t.Helper()
+	WithValues(t, map[string]string{field.Name(): value})
+}
+
+// WithValues sets the values of the named fields for the whole process until the test and all its subtests have
+// completed. Afterwards, the previous overrides of the fields (if any) are in effect again.
+func WithValues(t testing.TB, values map[string]string) {
+	t.Helper()
+	for name, value := range values {
+		t.Cleanup(override.Set(name, value))
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envtest_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/simia-tech/env/v2"
+	"github.com/simia-tech/env/v2/envtest"
+)
+
+var (
+	name = env.String("ENVTEST_NAME", "joe")
+	age  = env.Int("ENVTEST_AGE", 24)
+)
+
+func TestOverride(t *testing.T) {
+	t.Run("Name", func(t *testing.T) {
+		t.Parallel()
+		envtest.Override(t, name, "jane")
+		assert.Equal(t, "jane", name.GetOrDefault())
+		_, ok := os.LookupEnv("ENVTEST_NAME")
+		assert.False(t, ok)
+	})
+	t.Run("Age", func(t *testing.T) {
+		t.Parallel()
+		envtest.Override(t, age, "42")
+		assert.Equal(t, 42, age.GetOrDefault())
+	})
+	t.Run("Restore", func(t *testing.T) {
+		t.Run("Override", func(t *testing.T) {
+			envtest.Override(t, name, "jane")
+		})
+		assert.Equal(t, "joe", name.GetOrDefault())
+	})
+	t.Run("Nested", func(t *testing.T) {
+		envtest.Override(t, name, "jane")
+		t.Run("Override", func(t *testing.T) {
+			envtest.Override(t, name, "jim")
+			assert.Equal(t, "jim", name.GetOrDefault())
+		})
+		assert.Equal(t, "jane", name.GetOrDefault())
+	})
+}
+
+func TestWithValues(t *testing.T) {
+	envtest.WithValues(t, map[string]string{"ENVTEST_NAME": "jane", "ENVTEST_AGE": "42"})
+	assert.Equal(t, "jane", name.GetOrDefault())
+	assert.Equal(t, 42, age.GetOrDefault())
+}
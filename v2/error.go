@@ -19,16 +19,35 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sync"
 )
 
 var (
 	ErrRequiredValueIsMissing = errors.New("required value is missing")
 	ErrValueIsNotAllowed      = errors.New("value is not allowed")
+	ErrUndefinedReference     = errors.New("undefined reference")
+	ErrUnterminatedReference  = errors.New("unterminated reference")
+	ErrReferenceCycle         = errors.New("reference cycle")
+	ErrConflictingValues      = errors.New("conflicting values")
+	ErrDeprecated             = errors.New("deprecated")
+	ErrMutuallyExclusive      = errors.New("mutually exclusive")
 )
 
 // ErrorHandler defines a handler for error messages. By default, LogErrorHandler is set.
 var ErrorHandler = StderrErrorHandler
 
+// WarningHandler defines a handler for warnings, e.g. the use of a deprecated name. By default,
+// StderrErrorHandler is set.
+var WarningHandler = StderrErrorHandler
+
+var warned sync.Map
+
+func warnOnce(key string, err error) {
+	if _, loaded := warned.LoadOrStore(key, true); !loaded {
+		WarningHandler(err)
+	}
+}
+
 // Implementations of different error handlers.
 var (
 	NullErrorHandler   = func(error) {}
@@ -48,6 +67,12 @@ func joinStringValues(values []string) string {
 }
 
 func joinStrings(values []string, sepRune, sepWord string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	if len(values) == 1 {
+		return "'" + values[0] + "'"
+	}
 	text := ""
 	for index := 0; index < len(values)-1; index++ {
 		if index > 0 {
@@ -0,0 +1,101 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"fmt"
+	"strings"
+)
+
+func expand(value string, lookup lookupFunc, visiting []string) (string, error) {
+	offset := 0
+	s := strings.Builder{}
+	for {
+		start := strings.Index(value, "${")
+		if start < 0 {
+			s.WriteString(value)
+			return s.String(), nil
+		}
+		end := closingBrace(value, start)
+		if end < 0 {
+			return "", fmt.Errorf("reference at index %d: %w", offset+start, ErrUnterminatedReference)
+		}
+
+		name, defaultValue, hasDefault := value[start+2:end], "", false
+		if index := strings.Index(name, ":-"); index >= 0 {
+			name, defaultValue, hasDefault = name[:index], name[index+2:], true
+		}
+
+		resolved, err := resolveReference(name, lookup, visiting)
+		if err != nil {
+			return "", err
+		}
+		if resolved == "" {
+			if !hasDefault {
+				if _, ok := fields[name]; !ok {
+					return "", fmt.Errorf("reference [%s]: %w", name, ErrUndefinedReference)
+				}
+			}
+			if resolved, err = expand(defaultValue, lookup, visiting); err != nil {
+				return "", err
+			}
+		}
+
+		s.WriteString(value[:start])
+		s.WriteString(resolved)
+		value = value[end+1:]
+		offset += end + 1
+	}
+}
+
+// closingBrace returns the index of the brace, that closes the reference starting at start, or -1 if the reference
+// is unterminated. References nested in the default value are skipped.
+func closingBrace(value string, start int) int {
+	depth := 0
+	for index := start + 2; index < len(value); index++ {
+		switch {
+		case strings.HasPrefix(value[index:], "${"):
+			depth++
+			index++
+		case value[index] == '}':
+			if depth == 0 {
+				return index
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+func resolveReference(name string, lookup lookupFunc, visiting []string) (string, error) {
+	for _, v := range visiting {
+		if v == name {
+			return "", fmt.Errorf("reference [%s]: %w", strings.Join(append(visiting, name), " -> "), ErrReferenceCycle)
+		}
+	}
+
+	value := lookup(name)
+	field, ok := fields[name]
+	if !ok {
+		return value, nil
+	}
+	if value == "" {
+		value = field.DefaultValue()
+	}
+	if fieldOptions(field).expand {
+		return expand(value, lookup, append(visiting, name))
+	}
+	return value, nil
+}
@@ -16,7 +16,6 @@ package env
 
 import (
 	"fmt"
-	"os"
 	"regexp"
 	"runtime"
 	"strings"
@@ -30,15 +29,28 @@ type Field interface {
 	Description() string
 }
 
+type baseField interface {
+	base() *field
+}
+
 var fields = map[string]Field{}
 var nameRegexp = regexp.MustCompile("^[A-Z0-9_]+$")
 
-// RegisterField adds the provided `Field` to the global field-register.
+// RegisterField adds the provided `Field` to the global field-register. If a field with the same name is already
+// registered, that field is returned.
 func RegisterField(field Field) Field {
 	name := field.Name()
 	if !nameRegexp.MatchString(name) {
 		panic(fmt.Sprintf("field name [%s] must only contain capital letters, numbers or underscores", name))
 	}
+	for _, alias := range fieldOptions(field).aliases {
+		if !nameRegexp.MatchString(alias) {
+			panic(fmt.Sprintf("alias [%s] of field [%s] must only contain capital letters, numbers or underscores", alias, name))
+		}
+	}
+	if bf, ok := field.(baseField); ok {
+		bf.base().defaultText = field.DefaultValue
+	}
 	if f, ok := fields[name]; ok {
 		return f
 	}
@@ -46,7 +58,21 @@ func RegisterField(field Field) Field {
 	return field
 }
 
-// Fields returns a slice of strings with all registered fields.
+func fieldLabel(f Field) string {
+	if bf, ok := f.(baseField); ok {
+		return bf.base().label
+	}
+	return fmt.Sprintf("%T", f)
+}
+
+func fieldLocation(f Field) string {
+	if bf, ok := f.(baseField); ok {
+		return bf.base().location
+	}
+	return "unknown location"
+}
+
+// Fields returns a slice of strings with all registered fields. Use Infos to inspect the fields.
 func Fields() []string {
 	names := []string{}
 	for name := range fields {
@@ -55,16 +81,29 @@ func Fields() []string {
 	return names
 }
 
-// Clear clears the field register.
+func fieldOptions(f Field) *options {
+	if bf, ok := f.(baseField); ok {
+		return bf.base().options
+	}
+	return &options{}
+}
+
+// Clear clears the field register, all registered constraints and the values of bound flags.
 func Clear() {
 	fields = map[string]Field{}
+	constraints = []constraint{}
+	flagValues.Range(func(name, _ interface{}) bool {
+		flagValues.Delete(name)
+		return true
+	})
 }
 
 type field struct {
-	label    string
-	name     string
-	location string
-	options  *options
+	label       string
+	name        string
+	location    string
+	options     *options
+	defaultText func() string
 }
 
 func newField(label, name string, opts []Option) field {
@@ -81,25 +120,81 @@ func (f *field) Name() string {
 	return f.name
 }
 
-func (f *field) value() (string, error) {
-	value := os.Getenv(f.name)
+func (f *field) base() *field {
+	return f
+}
+
+func (f *field) value(lookup lookupFunc) (string, error) {
+	value, err := f.lookup(lookup)
+	if err != nil {
+		return "", err
+	}
+	if f.options.expand {
+		// A default value with references is expanded as well.
+		if value == "" && !f.options.required && f.defaultText != nil {
+			if text := f.defaultText(); strings.Contains(text, "${") {
+				value = text
+			}
+		}
+		expanded, err := expand(value, lookup, []string{f.name})
+		if err != nil {
+			return "", f.newError(value, err)
+		}
+		value = expanded
+	}
 	if f.options.required && value == "" {
-		return "", fmt.Errorf("field %s: %w", f.name, ErrRequiredValueIsMissing)
+		return "", f.newError(value, ErrRequiredValueIsMissing)
 	}
 	if !f.options.isAllowedValue(value) {
-		return "", fmt.Errorf("field %s with value [%s]: %w", f.name, value, ErrValueIsNotAllowed)
+		return "", f.newError(value, ErrValueIsNotAllowed)
 	}
 	return value, nil
 }
 
+func (f *field) lookup(lookup lookupFunc) (string, error) {
+	value := lookup(f.name)
+	if value != "" && f.options.deprecation != "" {
+		warnOnce(f.name, fmt.Errorf("field %s: %w: %s", f.name, ErrDeprecated, f.options.deprecation))
+	}
+	for _, alias := range f.options.aliases {
+		aliasValue := lookup(alias)
+		if aliasValue == "" {
+			continue
+		}
+		warnOnce(alias, fmt.Errorf("field %s: name %s: %w", f.name, alias, ErrDeprecated))
+		if value == "" {
+			value = aliasValue
+			continue
+		}
+		if aliasValue != value {
+			return "", f.newError(value, fmt.Errorf("name %s with value [%s]: %w", alias, aliasValue, ErrConflictingValues))
+		}
+	}
+	return value, nil
+}
+
+func (f *field) newError(raw string, cause error) error {
+	if raw == "" {
+		return fmt.Errorf("field %s: %w", f.name, cause)
+	}
+	return fmt.Errorf("field %s with value [%s]: %w", f.name, raw, cause)
+}
+
 func (f *field) description(defaultValue string) string {
 	if f.options.desc != "" {
 		return f.options.desc
 	}
 	sentences := []string{f.label + " field."}
+	if f.options.deprecation != "" {
+		sentences = append(sentences, "Deprecated: "+f.options.deprecation+".")
+	}
 	if f.options.required {
 		sentences = append(sentences, "Required field.")
 	}
+	sentences = append(sentences, constraintSentences(f.name)...)
+	if f.options.aliases != nil {
+		sentences = append(sentences, fmt.Sprintf("Deprecated names are %s.", joinStringValues(f.options.aliases)))
+	}
 	if f.options.allowedValues != nil {
 		sentences = append(sentences, fmt.Sprintf("Allowed values are %s.", joinStringValues(f.options.allowedValues)))
 	}
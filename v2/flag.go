@@ -0,0 +1,98 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"flag"
+	"strings"
+	"sync"
+)
+
+var flagValues sync.Map
+
+// BindFlags defines a flag in the provided flag set for each registered field with the option Flag or AutoFlag.
+// The fields can be further selected by the provided options. The usage text of each flag is the field's
+// description. A value given via a flag takes precedence over the value of the environment.
+func BindFlags(fs *flag.FlagSet, opts ...PrintOption) {
+	for _, field := range newPrintOptions(opts).selectFields() {
+		flagName := FlagName(field)
+		if flagName == "" {
+			continue
+		}
+		if fieldOptions(field).isBool {
+			fs.Var(&boolFlagValue{flagValue{field: field}}, flagName, field.Description())
+		} else {
+			fs.Var(&flagValue{field: field}, flagName, field.Description())
+		}
+	}
+}
+
+// FlagName returns the name of the flag that is bound to the provided field. If the field has neither the option
+// Flag nor AutoFlag, an empty string is returned.
+func FlagName(field Field) string {
+	fo := fieldOptions(field)
+	if fo.flag != "" {
+		return fo.flag
+	}
+	if fo.autoFlag {
+		return strings.ReplaceAll(strings.ToLower(field.Name()), "_", "-")
+	}
+	return ""
+}
+
+func lookupFlag(name string) (string, bool) {
+	value, ok := flagValues.Load(name)
+	if !ok {
+		return "", false
+	}
+	return value.(string), true
+}
+
+type flagValue struct {
+	field Field
+}
+
+func (fv *flagValue) String() string {
+	if fv == nil || fv.field == nil {
+		return ""
+	}
+	return fv.field.DefaultValue()
+}
+
+func (fv *flagValue) Set(value string) error {
+	name := fv.field.Name()
+	if v, ok := fv.field.(validator); ok {
+		lookup := currentLookup()
+		err := v.validate(func(n string) string {
+			if n == name {
+				return value
+			}
+			return lookup(n)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	flagValues.Store(name, value)
+	return nil
+}
+
+type boolFlagValue struct {
+	flagValue
+}
+
+func (bfv *boolFlagValue) IsBoolFlag() bool {
+	return true
+}
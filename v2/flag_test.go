@@ -0,0 +1,86 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v2"
+)
+
+func TestBindFlags(t *testing.T) {
+	newFlagSet := func() *flag.FlagSet {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		env.BindFlags(fs)
+		return fs
+	}
+
+	t.Run("Names", func(t *testing.T) {
+		env.Clear()
+		url := env.String("FLAG_DB_URL", "", env.Flag("db-url"))
+		port := env.Int("FLAG_PORT", 8080, env.AutoFlag(), env.Description("Port to listen on."))
+		other := env.String("FLAG_OTHER", "")
+
+		fs := newFlagSet()
+		assert.Equal(t, "db-url", env.FlagName(url))
+		assert.Equal(t, "flag-port", env.FlagName(port))
+		assert.Equal(t, "", env.FlagName(other))
+		require.NotNil(t, fs.Lookup("flag-port"))
+		assert.Equal(t, "Port to listen on.", fs.Lookup("flag-port").Usage)
+		assert.Equal(t, "8080", fs.Lookup("flag-port").DefValue)
+		assert.Nil(t, fs.Lookup("flag-other"))
+	})
+
+	t.Run("Precedence", func(t *testing.T) {
+		env.Clear()
+		defer os.Unsetenv("FLAG_ONE")
+		defer os.Unsetenv("FLAG_TWO")
+		one := env.String("FLAG_ONE", "default", env.AutoFlag())
+		two := env.String("FLAG_TWO", "default", env.AutoFlag())
+		three := env.String("FLAG_THREE", "default", env.AutoFlag())
+
+		require.NoError(t, os.Setenv("FLAG_ONE", "env"))
+		require.NoError(t, os.Setenv("FLAG_TWO", "env"))
+		require.NoError(t, newFlagSet().Parse([]string{"-flag-one", "flag"}))
+
+		assert.Equal(t, "flag", one.GetOrDefault())
+		assert.Equal(t, "env", two.GetOrDefault())
+		assert.Equal(t, "default", three.GetOrDefault())
+	})
+
+	t.Run("Bool", func(t *testing.T) {
+		env.Clear()
+		debug := env.Bool("FLAG_DEBUG", false, env.AutoFlag())
+
+		require.NoError(t, newFlagSet().Parse([]string{"-flag-debug"}))
+		assert.True(t, debug.GetOrDefault())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		env.Clear()
+		env.Int("FLAG_PORT", 8080, env.AutoFlag())
+
+		assert.Error(t, newFlagSet().Parse([]string{"-flag-port", "abc"}))
+	})
+
+	env.Clear()
+}
@@ -0,0 +1,109 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+// FieldInfo describes a registered field, its options and its current resolution. The values of sensitive fields
+// are not redacted.
+type FieldInfo struct {
+	Name          string
+	Type          string
+	DefaultValue  string
+	Description   string
+	Required      bool
+	AllowedValues []string
+	Constraints   []string
+	Sensitive     bool
+	Group         string
+	Tags          []string
+	Aliases       []string
+	Deprecation   string
+	Location      string
+
+	// Value holds the effective value of the field. If the field holds an invalid value, Value is the default value
+	// and Err holds the error.
+	Value  string
+	Source string
+	Err    error
+}
+
+// Infos returns the information of all registered fields sorted by name.
+func Infos() []FieldInfo {
+	lookup := currentLookup()
+	infos := []FieldInfo{}
+	for _, field := range newPrintOptions(nil).selectFields() {
+		infos = append(infos, newFieldInfo(field, lookup))
+	}
+	return infos
+}
+
+// Lookup returns the information of the registered field with the provided name. If no such field is registered,
+// false is returned.
+func Lookup(name string) (FieldInfo, bool) {
+	field, ok := fields[name]
+	if !ok {
+		return FieldInfo{}, false
+	}
+	return newFieldInfo(field, currentLookup()), true
+}
+
+func newFieldInfo(field Field, lookup lookupFunc) FieldInfo {
+	o := fieldOptions(field)
+	info := FieldInfo{
+		Name:          field.Name(),
+		Type:          fieldLabel(field),
+		DefaultValue:  field.DefaultValue(),
+		Description:   o.desc,
+		Required:      o.required,
+		AllowedValues: copyStrings(o.allowedValues),
+		Constraints:   constraintSentences(field.Name()),
+		Sensitive:     o.sensitive,
+		Group:         o.group,
+		Tags:          copyStrings(o.tags),
+		Aliases:       copyStrings(o.aliases),
+		Deprecation:   o.deprecation,
+		Location:      fieldLocation(field),
+		Source:        source(field.Name()),
+	}
+	if _, ok := field.(baseField); !ok {
+		info.Description = field.Description()
+	}
+	info.Value, info.Err = fieldValue(field, lookup)
+	return info
+}
+
+type texter interface {
+	text(lookupFunc) (string, error)
+}
+
+// fieldValue returns the field's value resolved via lookup, or the default value and the error. Unlike Value, it
+// doesn't report the error to the field's error handler.
+func fieldValue(field Field, lookup lookupFunc) (string, error) {
+	if t, ok := field.(texter); ok {
+		return t.text(lookup)
+	}
+	if v, ok := field.(validator); ok {
+		if err := v.validate(lookup); err != nil {
+			return field.DefaultValue(), err
+		}
+	}
+	return field.Value(), nil
+}
+
+func copyStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	return append([]string{}, values...)
+}
@@ -0,0 +1,65 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v2"
+	"github.com/simia-tech/env/v2/envtest"
+)
+
+func TestInfos(t *testing.T) {
+	env.Clear()
+	cert := env.String("INFO_CERT", "", env.Description("TLS certificate."), env.Group("tls"), env.Tags("server"))
+	key := env.Bytes("INFO_KEY", nil, env.Sensitive())
+	env.RequireTogether(cert, key)
+	env.Int("INFO_PORT", 8080, env.Required(), env.AllowedValues("80", "8080"), env.Aliases("PORT"))
+	envtest.WithValues(t, map[string]string{"INFO_CERT": "cert.pem", "INFO_PORT": "443"})
+
+	infos := env.Infos()
+	require.Len(t, infos, 3)
+	assert.Regexp(t, `info_test\.go:\d+$`, infos[0].Location)
+	infos[0].Location = ""
+	assert.Equal(t, env.FieldInfo{
+		Name:        "INFO_CERT",
+		Type:        "String",
+		Description: "TLS certificate.",
+		Constraints: []string{"Must be set together with 'INFO_KEY'."},
+		Group:       "tls",
+		Tags:        []string{"server"},
+		Value:       "cert.pem",
+		Source:      "override",
+	}, infos[0])
+	assert.Equal(t, "INFO_KEY", infos[1].Name)
+	assert.True(t, infos[1].Sensitive)
+	assert.Equal(t, "default", infos[1].Source)
+
+	info, ok := env.Lookup("INFO_PORT")
+	require.True(t, ok)
+	assert.Equal(t, "Int", info.Type)
+	assert.True(t, info.Required)
+	assert.Equal(t, []string{"80", "8080"}, info.AllowedValues)
+	assert.Equal(t, []string{"PORT"}, info.Aliases)
+	assert.Equal(t, "8080", info.Value)
+	assert.True(t, errors.Is(info.Err, env.ErrValueIsNotAllowed))
+
+	_, ok = env.Lookup("INFO_MISSING")
+	assert.False(t, ok)
+}
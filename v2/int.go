@@ -15,7 +15,6 @@
 package env
 
 import (
-	"fmt"
 	"strconv"
 )
 
@@ -62,7 +61,28 @@ func (f *IntField) GetOrDefault() int {
 
 // Get returns the field value or an error.
 func (f *IntField) Get() (int, error) {
-	v, err := f.value()
+	if value, ok := resolvedValue(f); ok {
+		return value.(int), nil
+	}
+	return f.get(currentLookup())
+}
+
+func (f *IntField) validate(lookup lookupFunc) error {
+	_, err := f.get(lookup)
+	return err
+}
+
+func (f *IntField) resolve(lookup lookupFunc) (interface{}, error) {
+	return f.get(lookup)
+}
+
+func (f *IntField) text(lookup lookupFunc) (string, error) {
+	value, err := f.get(lookup)
+	return strconv.Itoa(value), err
+}
+
+func (f *IntField) get(lookup lookupFunc) (int, error) {
+	v, err := f.value(lookup)
 	if err != nil {
 		return f.defaultValue, err
 	}
@@ -71,7 +91,7 @@ func (f *IntField) Get() (int, error) {
 	}
 	value, err := strconv.Atoi(v)
 	if err != nil {
-		return f.defaultValue, fmt.Errorf("field %s parse int [%s]: %w", f.name, v, err)
+		return f.defaultValue, f.newError(v, err)
 	}
 	return value, nil
 }
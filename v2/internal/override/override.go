@@ -0,0 +1,60 @@
+package override
+
+import "sync"
+
+type entry struct {
+	id    uint64
+	value string
+}
+
+var (
+	mutex  sync.RWMutex
+	values = map[string][]entry{}
+	lastID uint64
+)
+
+// Set overrides the value of the name and returns a function that removes the override again. Overrides of the same
+// name stack, the latest override that hasn't been removed is in effect.
+func Set(name, value string) func() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	lastID++
+	id := lastID
+	values[name] = append(values[name], entry{id: id, value: value})
+	return func() {
+		unset(name, id)
+	}
+}
+
+func unset(name string, id uint64) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	entries := values[name]
+	for index, e := range entries {
+		if e.id == id {
+			entries = append(entries[:index:index], entries[index+1:]...)
+			break
+		}
+	}
+	if len(entries) == 0 {
+		delete(values, name)
+		return
+	}
+	values[name] = entries
+}
+
+func Lookup(name string) (string, bool) {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	entries, ok := values[name]
+	if !ok {
+		return "", false
+	}
+	return entries[len(entries)-1].value, true
+}
+
+func Active() bool {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return len(values) > 0
+}
@@ -0,0 +1,32 @@
+package parser_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v2/internal/parser"
+)
+
+func TestParseKeyValuesErrors(t *testing.T) {
+	testFn := func(raw string, expectedIndex int, expectedErr error) (string, func(*testing.T)) {
+		return raw, func(t *testing.T) {
+			err := parser.ParseKeyValues(raw, func(string, string) error { return nil })
+			pe := (*parser.Error)(nil)
+			require.True(t, errors.As(err, &pe), "expected parser error, got %v", err)
+			assert.Equal(t, expectedIndex, pe.Index)
+			assert.True(t, errors.Is(err, expectedErr), "expected %v, got %v", expectedErr, err)
+		}
+	}
+
+	t.Run(testFn(`"abc`, 0, parser.ErrUnterminatedQuote))
+	t.Run(testFn(`one:'abc`, 4, parser.ErrUnterminatedQuote))
+	t.Run(testFn(`one:"abc\`, 8, parser.ErrDanglingEscape))
+	t.Run(testFn(`one:"a\u12"`, 6, parser.ErrDanglingEscape))
+	t.Run(testFn(`one:"a\q"`, 6, parser.ErrInvalidEscape))
+	t.Run(testFn(`one:"a\uZZZZ"`, 6, parser.ErrInvalidEscape))
+	t.Run(testFn(`one:"a"b`, 7, parser.ErrUnexpectedRune))
+	t.Run(testFn(`one,,two`, 4, parser.ErrEmptyKey))
+}
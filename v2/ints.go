@@ -64,7 +64,28 @@ func (f *IntsField) GetOrDefault() []int {
 
 // Get returns the field value or an error.
 func (f *IntsField) Get() ([]int, error) {
-	v, err := f.value()
+	if value, ok := resolvedValue(f); ok {
+		return value.([]int), nil
+	}
+	return f.get(currentLookup())
+}
+
+func (f *IntsField) validate(lookup lookupFunc) error {
+	_, err := f.get(lookup)
+	return err
+}
+
+func (f *IntsField) resolve(lookup lookupFunc) (interface{}, error) {
+	return f.get(lookup)
+}
+
+func (f *IntsField) text(lookup lookupFunc) (string, error) {
+	value, err := f.get(lookup)
+	return strings.Join(stringsValue(value), separator), err
+}
+
+func (f *IntsField) get(lookup lookupFunc) ([]int, error) {
+	v, err := f.value(lookup)
 	if err != nil {
 		return f.defaultValue, err
 	}
@@ -74,7 +95,7 @@ func (f *IntsField) Get() ([]int, error) {
 
 	values, err := parser.ParseInts(v)
 	if err != nil {
-		return f.defaultValue, err
+		return f.defaultValue, f.newError(v, err)
 	}
 	return values, nil
 }
@@ -0,0 +1,63 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v2"
+)
+
+func TestJSONSchema(t *testing.T) {
+	env.Clear()
+	env.String("TEST_ONE", "default", env.Required(), env.AllowedValues("one", "two"), env.Description("Mode."))
+	env.Int("TEST_TWO", 8080, env.Description("Port number."))
+	env.Bool("TEST_THREE", false, env.Description("Verbose output."))
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, env.JSONSchema(buffer))
+
+	schema := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &schema))
+
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, []interface{}{"TEST_ONE"}, schema["required"])
+	assert.Equal(t, map[string]interface{}{
+		"TEST_ONE": map[string]interface{}{
+			"type":        "string",
+			"description": "Mode.",
+			"default":     "default",
+			"enum":        []interface{}{"one", "two"},
+		},
+		"TEST_TWO": map[string]interface{}{
+			"type":        "string",
+			"description": "Port number.",
+			"default":     "8080",
+			"pattern":     `^[-+]?[0-9]+$|^$`,
+		},
+		"TEST_THREE": map[string]interface{}{
+			"type":        "string",
+			"description": "Verbose output.",
+			"default":     "false",
+			"pattern":     `^(1|true|yes|0|false|no)$|^$`,
+			"enum":        []interface{}{"0", "1", "false", "true", "no", "yes", ""},
+		},
+	}, schema["properties"])
+}
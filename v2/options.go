@@ -21,6 +21,15 @@ type options struct {
 	required      bool
 	allowedValues []string
 	desc          string
+	group         string
+	tags          []string
+	sensitive     bool
+	expand        bool
+	aliases       []string
+	deprecation   string
+	flag          string
+	autoFlag      bool
+	isBool        bool
 }
 
 func newOptions(opts []Option) *options {
@@ -52,6 +61,76 @@ func Description(text string) Option {
 	}
 }
 
+// Group returns an Option that assigns the environment field to the named group.
+func Group(name string) Option {
+	return func(o *options) {
+		o.group = name
+	}
+}
+
+// Tags returns an Option that adds the provided tags to the environment field.
+func Tags(tags ...string) Option {
+	return func(o *options) {
+		o.tags = append(o.tags, tags...)
+	}
+}
+
+// Sensitive returns an Option that marks the environment field as sensitive, e.g. a password or a key.
+func Sensitive() Option {
+	return func(o *options) {
+		o.sensitive = true
+	}
+}
+
+// Expand returns an Option that enables the expansion of references like `${NAME}` or `${NAME:-default}` in the
+// value of the environment field, or in the default value if the field is not set. References are resolved against
+// the registered fields and the environment. Undefined references and reference cycles are reported as errors.
+func Expand() Option {
+	return func(o *options) {
+		o.expand = true
+	}
+}
+
+// Aliases returns an Option that defines previous names of the environment field. If the field itself is not set,
+// the value is read from the first alias that is set. Each use of an alias is reported once to the WarningHandler.
+func Aliases(names ...string) Option {
+	return func(o *options) {
+		o.aliases = append(o.aliases, names...)
+	}
+}
+
+// Deprecated returns an Option that marks the environment field as deprecated. The provided message should tell
+// what to use instead. If the field is set, the deprecation is reported once to the WarningHandler.
+func Deprecated(message string) Option {
+	return func(o *options) {
+		o.deprecation = message
+	}
+}
+
+// Flag returns an Option that binds the environment field to the named command-line flag. See BindFlags.
+func Flag(name string) Option {
+	return func(o *options) {
+		o.flag = name
+	}
+}
+
+// AutoFlag returns an Option that binds the environment field to a command-line flag, that is named after the
+// field in kebab-case, e.g. the field `DB_URL` is bound to the flag `db-url`. See BindFlags.
+func AutoFlag() Option {
+	return func(o *options) {
+		o.autoFlag = true
+	}
+}
+
+func (o *options) hasTag(tag string) bool {
+	for _, t := range o.tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
 func (o *options) isAllowedValue(value string) bool {
 	if o == nil || o.allowedValues == nil {
 		return true
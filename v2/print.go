@@ -20,76 +20,143 @@ import (
 	"sort"
 )
 
-// Print prints the environment in the provided format.
-func Print(w io.Writer, format string) error {
-	eh := ErrorHandler
-	ErrorHandler = NullErrorHandler
-	defer func() {
-		ErrorHandler = eh
-	}()
-
-	switch format {
-	case "short-bash":
-		printShortBash(w)
-		return nil
-	case "long-bash":
-		printLongBash(w)
-		return nil
-	case "short-dockerfile":
-		printShortDockerfile(w)
-		return nil
-	case "long-dockerfile":
-		printLongDockerfile(w)
-		return nil
-	default:
-		return fmt.Errorf("unknown format '%s'. known values are 'short-bash', 'long-bash', 'short-dockerfile' and 'long-dockerfile'", format)
+const requiredPlaceholder = "<required>"
+
+// Printer defines a format in which the provided fields can be printed.
+type Printer interface {
+	Print(io.Writer, []Field) error
+}
+
+// PrinterFunc implements the Printer interface for a function.
+type PrinterFunc func(io.Writer, []Field) error
+
+// Print calls the function with the provided writer and fields.
+func (f PrinterFunc) Print(w io.Writer, fields []Field) error {
+	return f(w, fields)
+}
+
+var printers = map[string]Printer{
+	"short-bash":       PrinterFunc(printShortBash),
+	"long-bash":        PrinterFunc(printLongBash),
+	"short-dockerfile": PrinterFunc(printShortDockerfile),
+	"long-dockerfile":  PrinterFunc(printLongDockerfile),
+	"dotenv-example":   PrinterFunc(printDotenvExample),
+	"diff":             PrinterFunc(printDiff),
+	"missing":          PrinterFunc(printMissing),
+	"markdown":         PrinterFunc(printMarkdown),
+	"html":             PrinterFunc(printHTML),
+	"jsonschema":       PrinterFunc(printJSONSchema),
+	"terraform":        PrinterFunc(printTerraform),
+	"helm-values":      PrinterFunc(printHelmValues),
+	"helm-template":    PrinterFunc(printHelmTemplate),
+}
+
+// RegisterPrinter adds the provided `Printer` to the printer-register under the given format name. An existing
+// printer with the same name is replaced.
+func RegisterPrinter(name string, printer Printer) {
+	printers[name] = printer
+}
+
+// Printers returns a sorted slice with the format names of all registered printers.
+func Printers() []string {
+	names := make([]string, 0, len(printers))
+	for name := range printers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Print prints the environment in the provided format. By default, all fields are printed sorted by name, but
+// the selection and order can be modified by the provided options. Errors of the writer are returned.
+func Print(w io.Writer, format string, opts ...PrintOption) error {
+	printer, ok := printers[format]
+	if !ok {
+		return fmt.Errorf("unknown format '%s'. known values are %s", format, joinStringValues(Printers()))
 	}
+
+	return printer.Print(w, newPrintOptions(opts).selectFields())
 }
 
-func printShortBash(w io.Writer) {
-	forEachField(func(field Field) {
-		fmt.Fprintf(w, "%s=\"%s\"\n", field.Name(), field.Value())
-	})
+func printShortBash(w io.Writer, fields []Field) error {
+	ew := &errWriter{w: w}
+	lookup := currentLookup()
+	for _, field := range fields {
+		fmt.Fprintf(ew, "%s=\"%s\"\n", field.Name(), printValue(field, lookup))
+	}
+	return ew.err
 }
 
-func printLongBash(w io.Writer) {
-	forEachField(func(field Field) {
-		fmt.Fprintf(w, "\n")
-		fmt.Fprintf(w, "# %s\n", field.Description())
-		fmt.Fprintf(w, "%s=\"%s\"\n", field.Name(), field.Value())
-	})
+func printLongBash(w io.Writer, fields []Field) error {
+	ew := &errWriter{w: w}
+	lookup := currentLookup()
+	for _, field := range fields {
+		fmt.Fprintf(ew, "\n")
+		fmt.Fprintf(ew, "# %s\n", field.Description())
+		fmt.Fprintf(ew, "%s=\"%s\"\n", field.Name(), printValue(field, lookup))
+	}
+	return ew.err
 }
 
-func printShortDockerfile(w io.Writer) {
-	index := 0
-	forEachField(func(field Field) {
+func printShortDockerfile(w io.Writer, fields []Field) error {
+	ew := &errWriter{w: w}
+	lookup := currentLookup()
+	for index, field := range fields {
 		if index == 0 {
-			fmt.Fprintf(w, "ENV ")
+			fmt.Fprintf(ew, "ENV ")
 		} else {
-			fmt.Fprintf(w, " \\\n    ")
+			fmt.Fprintf(ew, " \\\n    ")
 		}
-		fmt.Fprintf(w, "%s=\"%s\"", field.Name(), field.Value())
-		index++
-	})
-	fmt.Fprintln(w)
+		fmt.Fprintf(ew, "%s=\"%s\"", field.Name(), printValue(field, lookup))
+	}
+	fmt.Fprintln(ew)
+	return ew.err
 }
 
-func printLongDockerfile(w io.Writer) {
-	forEachField(func(field Field) {
-		fmt.Fprintln(w)
-		fmt.Fprintf(w, "# %s\n", field.Description())
-		fmt.Fprintf(w, "ENV %s %s\n", field.Name(), field.Value())
-	})
+func printLongDockerfile(w io.Writer, fields []Field) error {
+	ew := &errWriter{w: w}
+	lookup := currentLookup()
+	for _, field := range fields {
+		fmt.Fprintln(ew)
+		fmt.Fprintf(ew, "# %s\n", field.Description())
+		fmt.Fprintf(ew, "ENV %s %s\n", field.Name(), printValue(field, lookup))
+	}
+	return ew.err
 }
 
-func forEachField(fn func(Field)) {
-	keys := make([]string, 0, len(fields))
-	for key := range fields {
-		keys = append(keys, key)
+func printDotenvExample(w io.Writer, fields []Field) error {
+	ew := &errWriter{w: w}
+	for _, field := range fields {
+		fmt.Fprintln(ew)
+		fmt.Fprintf(ew, "# %s\n", field.Description())
+		defaultValue := field.DefaultValue()
+		if defaultValue == "" && fieldOptions(field).required {
+			fmt.Fprintf(ew, "%s=\"%s\"\n", field.Name(), requiredPlaceholder)
+			continue
+		}
+		fmt.Fprintf(ew, "# %s=\"%s\"\n", field.Name(), defaultValue)
 	}
-	sort.Strings(keys)
+	return ew.err
+}
+
+// errWriter wraps a writer and keeps the first error that occurred. All subsequent writes are skipped.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
 
-	for _, key := range keys {
-		fn(fields[key])
+func (ew *errWriter) Write(p []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
 	}
+	n, err := ew.w.Write(p)
+	ew.err = err
+	return n, err
+}
+
+// printValue returns the field's value, or the default value if the field is invalid. Unlike Value, the error is
+// not reported to the field's error handler.
+func printValue(field Field, lookup lookupFunc) string {
+	value, _ := fieldValue(field, lookup)
+	return value
 }
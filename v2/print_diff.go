@@ -0,0 +1,53 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"fmt"
+	"io"
+)
+
+func printDiff(w io.Writer, fields []Field) error {
+	ew := &errWriter{w: w}
+	lookup := currentLookup()
+	for _, field := range fields {
+		raw := lookup(field.Name())
+		if raw == "" {
+			continue
+		}
+		// Invalid values are shown with the raw value, since the resolved value falls back to the default.
+		value, err := fieldValue(field, lookup)
+		if err != nil {
+			fmt.Fprintf(ew, "%s=\"%s\" # default: \"%s\", source: %s, error: %v\n", field.Name(), raw, field.DefaultValue(), source(field.Name()), err)
+			continue
+		}
+		if value == field.DefaultValue() {
+			continue
+		}
+		fmt.Fprintf(ew, "%s=\"%s\" # default: \"%s\", source: %s\n", field.Name(), value, field.DefaultValue(), source(field.Name()))
+	}
+	return ew.err
+}
+
+func printMissing(w io.Writer, fields []Field) error {
+	ew := &errWriter{w: w}
+	for _, field := range fields {
+		if !fieldOptions(field).required || currentLookup()(field.Name()) != "" {
+			continue
+		}
+		fmt.Fprintf(ew, "%s # %s\n", field.Name(), field.Description())
+	}
+	return ew.err
+}
@@ -0,0 +1,190 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+)
+
+var docColumns = []string{"Name", "Type", "Default", "Required", "Allowed values", "Constraints", "Description", "Defined at"}
+
+type docRow struct {
+	name          string
+	typeLabel     string
+	defaultValue  string
+	required      bool
+	allowedValues []string
+	constraints   []string
+	description   string
+	location      string
+}
+
+func newDocRow(f Field) docRow {
+	row := docRow{
+		name:         f.Name(),
+		defaultValue: f.DefaultValue(),
+		description:  f.Description(),
+	}
+	if bf, ok := f.(baseField); ok {
+		b := bf.base()
+		row.typeLabel = b.label
+		row.required = b.options.required
+		for _, value := range b.options.allowedValues {
+			if value != "" {
+				row.allowedValues = append(row.allowedValues, value)
+			}
+		}
+		row.description = b.options.desc
+		row.constraints = constraintSentences(row.name)
+		if b.options.aliases != nil {
+			row.description = strings.TrimSpace(fmt.Sprintf("Deprecated names are %s. %s", joinStringValues(b.options.aliases), row.description))
+		}
+		if b.options.deprecation != "" {
+			row.description = strings.TrimSpace(fmt.Sprintf("Deprecated: %s. %s", b.options.deprecation, row.description))
+		}
+		row.location = b.location
+	}
+	return row
+}
+
+// docGroups splits the fields into groups of the same `Group` option. The fields without a group come first, the
+// other groups are sorted by name. The order of the fields within a group is kept.
+func docGroups(fields []Field) ([]string, map[string][]Field) {
+	names := []string{}
+	groups := map[string][]Field{}
+	for _, field := range fields {
+		group := fieldOptions(field).group
+		if _, ok := groups[group]; !ok {
+			names = append(names, group)
+		}
+		groups[group] = append(groups[group], field)
+	}
+	sort.Strings(names)
+	return names, groups
+}
+
+func printMarkdown(w io.Writer, fields []Field) error {
+	ew := &errWriter{w: w}
+	names, groups := docGroups(fields)
+	for index, name := range names {
+		if index > 0 {
+			fmt.Fprintln(ew)
+		}
+		if name != "" {
+			fmt.Fprintf(ew, "### %s\n\n", markdownEscape(name))
+		}
+		printMarkdownTable(ew, groups[name])
+	}
+	return ew.err
+}
+
+func printMarkdownTable(w io.Writer, fields []Field) {
+	fmt.Fprintf(w, "| %s |\n", strings.Join(docColumns, " | "))
+	fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", len(docColumns)))
+	for _, field := range fields {
+		row := newDocRow(field)
+		values := make([]string, len(row.allowedValues))
+		for index, value := range row.allowedValues {
+			values[index] = markdownCode(value)
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join([]string{
+			markdownCode(row.name),
+			markdownEscape(row.typeLabel),
+			markdownCode(row.defaultValue),
+			yesNo(row.required),
+			strings.Join(values, ", "),
+			markdownEscape(strings.Join(row.constraints, " ")),
+			markdownEscape(row.description),
+			markdownEscape(row.location),
+		}, " | "))
+	}
+}
+
+func printHTML(w io.Writer, fields []Field) error {
+	ew := &errWriter{w: w}
+	fmt.Fprintln(ew, "<table>")
+	fmt.Fprintln(ew, "  <thead>")
+	fmt.Fprint(ew, "    <tr>")
+	for _, column := range docColumns {
+		fmt.Fprintf(ew, "<th>%s</th>", html.EscapeString(column))
+	}
+	fmt.Fprintln(ew, "</tr>")
+	fmt.Fprintln(ew, "  </thead>")
+	fmt.Fprintln(ew, "  <tbody>")
+	names, groups := docGroups(fields)
+	for _, name := range names {
+		if name != "" {
+			fmt.Fprintf(ew, "    <tr><th colspan=\"%d\">%s</th></tr>\n", len(docColumns), html.EscapeString(name))
+		}
+		printHTMLRows(ew, groups[name])
+	}
+	fmt.Fprintln(ew, "  </tbody>")
+	fmt.Fprintln(ew, "</table>")
+	return ew.err
+}
+
+func printHTMLRows(w io.Writer, fields []Field) {
+	for _, field := range fields {
+		row := newDocRow(field)
+		values := make([]string, len(row.allowedValues))
+		for index, value := range row.allowedValues {
+			values[index] = htmlCode(value)
+		}
+		fmt.Fprint(w, "    <tr>")
+		for _, cell := range []string{
+			htmlCode(row.name),
+			html.EscapeString(row.typeLabel),
+			htmlCode(row.defaultValue),
+			yesNo(row.required),
+			strings.Join(values, ", "),
+			html.EscapeString(strings.Join(row.constraints, " ")),
+			html.EscapeString(row.description),
+			html.EscapeString(row.location),
+		} {
+			fmt.Fprintf(w, "<td>%s</td>", cell)
+		}
+		fmt.Fprintln(w, "</tr>")
+	}
+}
+
+func markdownEscape(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
+func markdownCode(text string) string {
+	if text == "" {
+		return ""
+	}
+	return "`" + markdownEscape(text) + "`"
+}
+
+func htmlCode(text string) string {
+	if text == "" {
+		return ""
+	}
+	return "<code>" + html.EscapeString(text) + "</code>"
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
@@ -22,13 +22,22 @@ import (
 
 // ParseFlags tests if the print-flag was given at the program start and prints the registered
 // environment fields with thier values to stdout using the specified format. Afterwards, the program exits
-// with return code 2.
+// with return code 2. If the check-flag was given, the environment is validated and the program exits with
+// return code 0 or 1. The flags of fields with the option Flag or AutoFlag are bound as well (see BindFlags).
 func ParseFlags() {
+	BindFlags(flag.CommandLine)
 	printEnvFlag := flag.Bool("print-env", false, "print the environment with the current values")
-	printEnvFormatFlag := flag.String("print-env-format", "short-bash", "print the environment in the given format. format can be 'short-bash', 'long-bash', 'short-dockerfile' and 'long-dockerfile'")
+	printEnvFormatFlag := flag.String("print-env-format", "short-bash", "print the environment in the given format. format can be "+joinStringValues(Printers()))
+	checkEnvFlag := flag.Bool("check-env", false, "check the environment for invalid values and violated constraints")
 
 	flag.Parse()
 
+	if *checkEnvFlag {
+		if err := Validate(); err != nil {
+			log.Fatal(err)
+		}
+		os.Exit(0)
+	}
 	if !*printEnvFlag {
 		return
 	}
@@ -0,0 +1,43 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const helmValuesKey = "env"
+
+func printHelmValues(w io.Writer, fields []Field) error {
+	ew := &errWriter{w: w}
+	fmt.Fprintf(ew, "%s:\n", helmValuesKey)
+	for _, field := range fields {
+		fmt.Fprintf(ew, "  # %s\n", field.Description())
+		fmt.Fprintf(ew, "  %s: %s\n", field.Name(), strconv.Quote(field.DefaultValue()))
+	}
+	return ew.err
+}
+
+func printHelmTemplate(w io.Writer, fields []Field) error {
+	ew := &errWriter{w: w}
+	fmt.Fprintf(ew, "env:\n")
+	for _, field := range fields {
+		fmt.Fprintf(ew, "  - name: %s\n", field.Name())
+		fmt.Fprintf(ew, "    value: {{ .Values.%s.%s | quote }}\n", helmValuesKey, field.Name())
+	}
+	return ew.err
+}
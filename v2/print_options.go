@@ -0,0 +1,122 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"sort"
+	"strings"
+)
+
+// PrintOption defines an option that modifies the selection and order of the printed fields.
+type PrintOption func(*printOptions)
+
+type printOptions struct {
+	prefix           string
+	group            *string
+	tag              string
+	sortByGroup      bool
+	excludeSensitive bool
+	requiredOnly     bool
+}
+
+func newPrintOptions(opts []PrintOption) *printOptions {
+	o := &printOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// PrintPrefix returns a PrintOption that selects only the fields with a name that starts with the provided prefix.
+func PrintPrefix(prefix string) PrintOption {
+	return func(o *printOptions) {
+		o.prefix = prefix
+	}
+}
+
+// PrintGroup returns a PrintOption that selects only the fields of the named group.
+func PrintGroup(name string) PrintOption {
+	return func(o *printOptions) {
+		o.group = &name
+	}
+}
+
+// PrintTag returns a PrintOption that selects only the fields with the provided tag.
+func PrintTag(tag string) PrintOption {
+	return func(o *printOptions) {
+		o.tag = tag
+	}
+}
+
+// PrintSortByGroup returns a PrintOption that sorts the fields by group first and by name second. By default,
+// the fields are sorted by name.
+func PrintSortByGroup() PrintOption {
+	return func(o *printOptions) {
+		o.sortByGroup = true
+	}
+}
+
+// PrintWithoutSensitive returns a PrintOption that excludes all sensitive fields.
+func PrintWithoutSensitive() PrintOption {
+	return func(o *printOptions) {
+		o.excludeSensitive = true
+	}
+}
+
+// PrintRequiredOnly returns a PrintOption that selects only the required fields.
+func PrintRequiredOnly() PrintOption {
+	return func(o *printOptions) {
+		o.requiredOnly = true
+	}
+}
+
+func (o *printOptions) selectFields() []Field {
+	result := []Field{}
+	for _, field := range fields {
+		if o.isSelected(field) {
+			result = append(result, field)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if o.sortByGroup {
+			gi, gj := fieldOptions(result[i]).group, fieldOptions(result[j]).group
+			if gi != gj {
+				return gi < gj
+			}
+		}
+		return result[i].Name() < result[j].Name()
+	})
+	return result
+}
+
+func (o *printOptions) isSelected(field Field) bool {
+	if !strings.HasPrefix(field.Name(), o.prefix) {
+		return false
+	}
+	fo := fieldOptions(field)
+	if o.group != nil && fo.group != *o.group {
+		return false
+	}
+	if o.tag != "" && !fo.hasTag(o.tag) {
+		return false
+	}
+	if o.excludeSensitive && fo.sensitive {
+		return false
+	}
+	if o.requiredOnly && !fo.required {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,207 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/simia-tech/env/v2/internal/override"
+)
+
+type lookupFunc func(string) string
+
+type validator interface {
+	validate(lookupFunc) error
+}
+
+type resolver interface {
+	resolve(lookupFunc) (interface{}, error)
+}
+
+// Snapshot holds the values of the environment at the time it has been loaded and the typed values of all fields,
+// that have been registered at that time. A snapshot is immutable. The slices and maps returned by the typed
+// accessors are shared and must not be modified.
+type Snapshot struct {
+	values   map[string]string
+	lookup   lookupFunc
+	resolved map[Field]interface{}
+}
+
+var activeSnapshot atomic.Value
+
+// Load reads the environment and the values of bound flags into a new snapshot and validates all registered fields
+// and constraints against it. If all values are valid, the typed values of the fields are resolved once and the
+// snapshot is activated and returned. As long as a snapshot is active, the fields return the resolved values
+// instead of reading the environment. Calling Load again replaces the active snapshot atomically.
+func Load() (*Snapshot, error) {
+	s := &Snapshot{values: map[string]string{}}
+	for _, entry := range os.Environ() {
+		if index := strings.IndexRune(entry, '='); index > 0 {
+			s.values[entry[:index]] = entry[index+1:]
+		}
+	}
+	flagValues.Range(func(name, value interface{}) bool {
+		s.values[name.(string)] = value.(string)
+		return true
+	})
+	if err := validate(s); err != nil {
+		return nil, err
+	}
+	s.resolved = map[Field]interface{}{}
+	for _, field := range fields {
+		if r, ok := field.(resolver); ok {
+			if value, err := r.resolve(s.Value); err == nil {
+				s.resolved[field] = value
+			}
+		}
+	}
+
+	activeSnapshot.Store(s)
+	return s, nil
+}
+
+// Unload deactivates the active snapshot, so the fields are read from the environment again.
+func Unload() {
+	activeSnapshot.Store((*Snapshot)(nil))
+}
+
+// Value returns the raw value of the named field or variable in the snapshot.
+func (s *Snapshot) Value(name string) string {
+	if s.lookup != nil {
+		return s.lookup(name)
+	}
+	return s.values[name]
+}
+
+// Bool returns the value of the provided field in the snapshot.
+func (s *Snapshot) Bool(f *BoolField) bool {
+	if value, ok := s.resolved[f]; ok {
+		return value.(bool)
+	}
+	value, _ := f.get(s.Value)
+	return value
+}
+
+// Bytes returns the value of the provided field in the snapshot.
+func (s *Snapshot) Bytes(f *BytesField) []byte {
+	if value, ok := s.resolved[f]; ok {
+		return value.([]byte)
+	}
+	value, _ := f.get(s.Value)
+	return value
+}
+
+// Duration returns the value of the provided field in the snapshot.
+func (s *Snapshot) Duration(f *DurationField) time.Duration {
+	if value, ok := s.resolved[f]; ok {
+		return value.(time.Duration)
+	}
+	value, _ := f.get(s.Value)
+	return value
+}
+
+// Int returns the value of the provided field in the snapshot.
+func (s *Snapshot) Int(f *IntField) int {
+	if value, ok := s.resolved[f]; ok {
+		return value.(int)
+	}
+	value, _ := f.get(s.Value)
+	return value
+}
+
+// Ints returns the value of the provided field in the snapshot.
+func (s *Snapshot) Ints(f *IntsField) []int {
+	if value, ok := s.resolved[f]; ok {
+		return value.([]int)
+	}
+	value, _ := f.get(s.Value)
+	return value
+}
+
+// String returns the value of the provided field in the snapshot.
+func (s *Snapshot) String(f *StringField) string {
+	if value, ok := s.resolved[f]; ok {
+		return value.(string)
+	}
+	value, _ := f.get(s.Value)
+	return value
+}
+
+// StringMap returns the value of the provided field in the snapshot.
+func (s *Snapshot) StringMap(f *StringMapField) map[string]string {
+	if value, ok := s.resolved[f]; ok {
+		return value.(map[string]string)
+	}
+	value, _ := f.get(s.Value)
+	return value
+}
+
+// Strings returns the value of the provided field in the snapshot.
+func (s *Snapshot) Strings(f *StringsField) []string {
+	if value, ok := s.resolved[f]; ok {
+		return value.([]string)
+	}
+	value, _ := f.get(s.Value)
+	return value
+}
+
+// resolvedValue returns the typed value of the field in the active snapshot. Fields, that are overridden or bound to
+// a flag, are not served from the snapshot, since these values take precedence.
+func resolvedValue(f Field) (interface{}, bool) {
+	s, _ := activeSnapshot.Load().(*Snapshot)
+	if s == nil || override.Active() {
+		return nil, false
+	}
+	if _, ok := lookupFlag(f.Name()); ok {
+		return nil, false
+	}
+	value, ok := s.resolved[f]
+	return value, ok
+}
+
+func currentLookup() lookupFunc {
+	lookup := os.Getenv
+	if s, _ := activeSnapshot.Load().(*Snapshot); s != nil {
+		lookup = s.Value
+	}
+	return func(name string) string {
+		if value, ok := override.Lookup(name); ok {
+			return value
+		}
+		if value, ok := lookupFlag(name); ok {
+			return value
+		}
+		return lookup(name)
+	}
+}
+
+func source(name string) string {
+	if currentLookup()(name) == "" {
+		return "default"
+	}
+	if _, ok := override.Lookup(name); ok {
+		return "override"
+	}
+	if _, ok := lookupFlag(name); ok {
+		return "flag"
+	}
+	if s, _ := activeSnapshot.Load().(*Snapshot); s != nil {
+		return "snapshot"
+	}
+	return "environment"
+}
@@ -0,0 +1,75 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v2"
+	"github.com/simia-tech/env/v2/envtest"
+)
+
+func TestLoad(t *testing.T) {
+	env.Clear()
+	defer env.Unload()
+	defer os.Unsetenv("SNAPSHOT_FIELD")
+
+	field := env.Int("SNAPSHOT_FIELD", 1)
+
+	require.NoError(t, os.Setenv("SNAPSHOT_FIELD", "2"))
+	snapshot, err := env.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "2", snapshot.Value("SNAPSHOT_FIELD"))
+	assert.Equal(t, 2, snapshot.Int(field))
+
+	require.NoError(t, os.Setenv("SNAPSHOT_FIELD", "3"))
+	assert.Equal(t, 2, field.GetOrDefault())
+
+	require.NoError(t, os.Setenv("SNAPSHOT_FIELD", "invalid"))
+	_, err = env.Load()
+	require.Error(t, err)
+	assert.Equal(t, 2, field.GetOrDefault())
+
+	env.Unload()
+	require.NoError(t, os.Setenv("SNAPSHOT_FIELD", "4"))
+	assert.Equal(t, 4, field.GetOrDefault())
+}
+
+func TestLoadResolvesTypedValues(t *testing.T) {
+	env.Clear()
+	defer env.Unload()
+	defer os.Unsetenv("SNAPSHOT_NAMES")
+
+	names := env.Strings("SNAPSHOT_NAMES", nil)
+	port := env.Int("SNAPSHOT_PORT", 8080)
+
+	require.NoError(t, os.Setenv("SNAPSHOT_NAMES", "a,b"))
+	snapshot, err := env.Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, snapshot.Strings(names))
+	assert.Equal(t, []string{"a", "b"}, names.GetOrDefault())
+	assert.Equal(t, 8080, snapshot.Int(port))
+
+	late := env.String("SNAPSHOT_LATE", "default")
+	assert.Equal(t, "default", snapshot.String(late))
+
+	envtest.Override(t, port, "9090")
+	assert.Equal(t, 9090, port.GetOrDefault())
+	assert.Equal(t, 8080, snapshot.Int(port))
+}
@@ -57,7 +57,28 @@ func (f *StringField) GetOrDefault() string {
 
 // Get returns the field value or an error.
 func (f *StringField) Get() (string, error) {
-	v, err := f.value()
+	if value, ok := resolvedValue(f); ok {
+		return value.(string), nil
+	}
+	return f.get(currentLookup())
+}
+
+func (f *StringField) validate(lookup lookupFunc) error {
+	_, err := f.get(lookup)
+	return err
+}
+
+func (f *StringField) resolve(lookup lookupFunc) (interface{}, error) {
+	return f.get(lookup)
+}
+
+func (f *StringField) text(lookup lookupFunc) (string, error) {
+	value, err := f.get(lookup)
+	return value, err
+}
+
+func (f *StringField) get(lookup lookupFunc) (string, error) {
+	v, err := f.value(lookup)
 	if err != nil {
 		return f.defaultValue, err
 	}
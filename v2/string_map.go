@@ -15,8 +15,6 @@
 package env
 
 import (
-	"fmt"
-
 	"github.com/simia-tech/env/v2/internal/parser"
 )
 
@@ -63,7 +61,28 @@ func (f *StringMapField) GetOrDefault() map[string]string {
 
 // Get returns the field value or an error
 func (f *StringMapField) Get() (map[string]string, error) {
-	v, err := f.value()
+	if value, ok := resolvedValue(f); ok {
+		return value.(map[string]string), nil
+	}
+	return f.get(currentLookup())
+}
+
+func (f *StringMapField) validate(lookup lookupFunc) error {
+	_, err := f.get(lookup)
+	return err
+}
+
+func (f *StringMapField) resolve(lookup lookupFunc) (interface{}, error) {
+	return f.get(lookup)
+}
+
+func (f *StringMapField) text(lookup lookupFunc) (string, error) {
+	value, err := f.get(lookup)
+	return parser.FormatStringMap(value), err
+}
+
+func (f *StringMapField) get(lookup lookupFunc) (map[string]string, error) {
+	v, err := f.value(lookup)
 	if err != nil {
 		return f.defaultValue, err
 	}
@@ -73,7 +92,7 @@ func (f *StringMapField) Get() (map[string]string, error) {
 
 	values, err := parser.ParseStringMap(v)
 	if err != nil {
-		return f.defaultValue, fmt.Errorf("parse string map: %w", err)
+		return f.defaultValue, f.newError(v, err)
 	}
 	return values, nil
 }
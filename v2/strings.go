@@ -65,7 +65,28 @@ func (f *StringsField) GetOrDefault() []string {
 
 // Get returns the field value or an error
 func (f *StringsField) Get() ([]string, error) {
-	v, err := f.value()
+	if value, ok := resolvedValue(f); ok {
+		return value.([]string), nil
+	}
+	return f.get(currentLookup())
+}
+
+func (f *StringsField) validate(lookup lookupFunc) error {
+	_, err := f.get(lookup)
+	return err
+}
+
+func (f *StringsField) resolve(lookup lookupFunc) (interface{}, error) {
+	return f.get(lookup)
+}
+
+func (f *StringsField) text(lookup lookupFunc) (string, error) {
+	value, err := f.get(lookup)
+	return strings.Join(value, separator), err
+}
+
+func (f *StringsField) get(lookup lookupFunc) ([]string, error) {
+	v, err := f.value(lookup)
 	if err != nil {
 		return f.defaultValue, err
 	}
@@ -75,7 +96,7 @@ func (f *StringsField) Get() ([]string, error) {
 
 	values, err := parser.ParseStrings(v)
 	if err != nil {
-		return f.defaultValue, err
+		return f.defaultValue, f.newError(v, err)
 	}
 	return values, nil
 }
@@ -0,0 +1,334 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/simia-tech/env/v3"
+	"github.com/simia-tech/env/v3/internal/location"
+)
+
+const (
+	envPathV2 = "github.com/simia-tech/env/v2"
+	envPathV3 = "github.com/simia-tech/env/v3"
+)
+
+const loadMode = packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedImports | packages.NeedDeps |
+	packages.NeedTypes | packages.NeedTypesInfo
+
+var (
+	constructors = map[string]bool{
+		"Bool":      true,
+		"Bytes":     true,
+		"Duration":  true,
+		"Int":       true,
+		"Ints":      true,
+		"String":    true,
+		"Strings":   true,
+		"StringMap": true,
+	}
+	// typeArguments maps the type arguments of the version 3 function `env.Field` to the matching version 2
+	// constructors.
+	typeArguments = map[string]string{
+		"bool":              "Bool",
+		"[]byte":            "Bytes",
+		"time.Duration":     "Duration",
+		"int":               "Int",
+		"[]int":             "Ints",
+		"string":            "String",
+		"[]string":          "Strings",
+		"map[string]string": "StringMap",
+	}
+	nameRegexp = regexp.MustCompile("^[A-Z0-9_]+$")
+)
+
+// extract loads the packages matching the patterns and registers each field declaration found in them. Declarations
+// of both version 2 and version 3 are registered as version 3 fields. Declarations that can't be registered, because of an invalid or already registered name, are skipped and returned as
+// diagnostics.
+func extract(dir string, patterns []string) ([]string, error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: loadMode, Dir: dir}, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("load packages: %w", err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf("packages contain errors")
+	}
+
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	diagnostics := []string{}
+	for _, pkg := range pkgs {
+		e := extractor{fset: pkg.Fset, info: pkg.TypesInfo, dir: absDir}
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(node ast.Node) bool {
+				if call, ok := node.(*ast.CallExpr); ok {
+					e.register(call)
+				}
+				return true
+			})
+		}
+		diagnostics = append(diagnostics, e.diagnostics...)
+	}
+	return diagnostics, nil
+}
+
+type extractor struct {
+	fset        *token.FileSet
+	info        *types.Info
+	dir         string
+	diagnostics []string
+}
+
+func (e *extractor) register(call *ast.CallExpr) {
+	constructor := e.fieldConstructor(call)
+	if constructor == "" || len(call.Args) < 2 {
+		return
+	}
+	name, ok := e.stringValue(call.Args[0])
+	if !ok {
+		return
+	}
+
+	position := e.fset.Position(call.Pos())
+	filename := position.Filename
+	if rel, err := filepath.Rel(e.dir, filename); err == nil && !strings.HasPrefix(rel, "..") {
+		filename = rel
+	}
+	definedAt := fmt.Sprintf("%s:%d", filename, position.Line)
+
+	// The fields are registered in the registry of this command, so names that would panic or be reported as
+	// duplicates there are skipped.
+	if !nameRegexp.MatchString(name) {
+		e.diagnostics = append(e.diagnostics, fmt.Sprintf("%s: field name %q must only contain capital letters, numbers or underscores", definedAt, name))
+		return
+	}
+	if info, ok := env.Lookup(name); ok {
+		e.diagnostics = append(e.diagnostics, fmt.Sprintf("%s: field %s is already declared at %s", definedAt, name, info.Location))
+		return
+	}
+
+	opts := []env.Option{location.Option(definedAt).(env.Option)}
+	for _, arg := range call.Args[2:] {
+		if opt := e.option(arg); opt != nil {
+			opts = append(opts, opt)
+		}
+	}
+
+	defaultValue := call.Args[1]
+	switch constructor {
+	case "Bool":
+		value, _ := e.constantValue(defaultValue)
+		env.Field(name, value != nil && value.Kind() == constant.Bool && constant.BoolVal(value), opts...)
+	case "Bytes":
+		value, _ := e.bytesValue(defaultValue)
+		env.Field(name, value, opts...)
+	case "Duration":
+		value, _ := e.intValue(defaultValue)
+		env.Field(name, time.Duration(value), opts...)
+	case "Int":
+		value, _ := e.intValue(defaultValue)
+		env.Field(name, int(value), opts...)
+	case "Ints":
+		values := []int{}
+		for _, elt := range e.elements(defaultValue) {
+			if value, ok := e.intValue(elt); ok {
+				values = append(values, int(value))
+			}
+		}
+		env.Field(name, values, opts...)
+	case "String":
+		value, _ := e.stringValue(defaultValue)
+		env.Field(name, value, opts...)
+	case "Strings":
+		env.Field(name, e.stringValues(e.elements(defaultValue)), opts...)
+	case "StringMap":
+		values := map[string]string{}
+		for _, elt := range e.elements(defaultValue) {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				key, keyOK := e.stringValue(kv.Key)
+				value, valueOK := e.stringValue(kv.Value)
+				if keyOK && valueOK {
+					values[key] = value
+				}
+			}
+		}
+		env.Field(name, values, opts...)
+	}
+}
+
+func (e *extractor) option(expr ast.Expr) env.Option {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || call.Ellipsis.IsValid() {
+		return nil
+	}
+	values := e.stringValues(call.Args)
+	if len(values) != len(call.Args) {
+		return nil
+	}
+	switch e.calledFunction(call) {
+	case "Required":
+		return env.Required()
+	case "Sensitive":
+		return env.Sensitive()
+	case "Expand":
+		return env.Expand()
+	case "AutoFlag":
+		return env.AutoFlag()
+	case "AllowedValues":
+		return env.AllowedValues(values...)
+	case "Tags":
+		return env.Tags(values...)
+	case "Aliases":
+		return env.Aliases(values...)
+	}
+	if len(values) != 1 {
+		return nil
+	}
+	switch e.calledFunction(call) {
+	case "Description":
+		return env.Description(values[0])
+	case "Group":
+		return env.Group(values[0])
+	case "Deprecated":
+		return env.Deprecated(values[0])
+	case "Flag":
+		return env.Flag(values[0])
+	}
+	return nil
+}
+
+// fieldConstructor returns the name of the version 2 constructor, that is called to declare a field. For the
+// version 3 function `env.Field`, the constructor is derived from the type argument of the instantiation. If the
+// call doesn't declare a field, an empty string is returned.
+func (e *extractor) fieldConstructor(call *ast.CallExpr) string {
+	fn, ident := e.calledEnvFunction(call)
+	if fn == nil {
+		return ""
+	}
+	if fn.Pkg().Path() == envPathV3 {
+		if fn.Name() != "Field" {
+			return ""
+		}
+		instance, ok := e.info.Instances[ident]
+		if !ok || instance.TypeArgs.Len() != 1 {
+			return ""
+		}
+		return typeArguments[types.TypeString(instance.TypeArgs.At(0), nil)]
+	}
+	if !constructors[fn.Name()] {
+		return ""
+	}
+	return fn.Name()
+}
+
+func (e *extractor) calledFunction(call *ast.CallExpr) string {
+	fn, _ := e.calledEnvFunction(call)
+	if fn == nil {
+		return ""
+	}
+	return fn.Name()
+}
+
+// calledEnvFunction returns the package level function of the env package of version 2 or 3, that is called,
+// together with the identifier that refers to it. Explicit instantiations like `env.Field[int]` are unwrapped.
+func (e *extractor) calledEnvFunction(call *ast.CallExpr) (*types.Func, *ast.Ident) {
+	fun := call.Fun
+	switch index := fun.(type) {
+	case *ast.IndexExpr:
+		fun = index.X
+	case *ast.IndexListExpr:
+		fun = index.X
+	}
+	var ident *ast.Ident
+	switch f := fun.(type) {
+	case *ast.SelectorExpr:
+		ident = f.Sel
+	case *ast.Ident:
+		ident = f
+	default:
+		return nil, nil
+	}
+	fn, ok := e.info.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil || (fn.Pkg().Path() != envPathV2 && fn.Pkg().Path() != envPathV3) {
+		return nil, nil
+	}
+	if sig, ok := fn.Type().(*types.Signature); !ok || sig.Recv() != nil {
+		return nil, nil
+	}
+	return fn, ident
+}
+
+func (e *extractor) constantValue(expr ast.Expr) (constant.Value, bool) {
+	tv, ok := e.info.Types[expr]
+	if !ok || tv.Value == nil {
+		return nil, false
+	}
+	return tv.Value, true
+}
+
+func (e *extractor) stringValue(expr ast.Expr) (string, bool) {
+	value, ok := e.constantValue(expr)
+	if !ok || value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(value), true
+}
+
+func (e *extractor) stringValues(exprs []ast.Expr) []string {
+	values := []string{}
+	for _, expr := range exprs {
+		if value, ok := e.stringValue(expr); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func (e *extractor) intValue(expr ast.Expr) (int64, bool) {
+	value, ok := e.constantValue(expr)
+	if !ok || value.Kind() != constant.Int {
+		return 0, false
+	}
+	return constant.Int64Val(value)
+}
+
+func (e *extractor) bytesValue(expr ast.Expr) ([]byte, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil, false
+	}
+	value, ok := e.stringValue(call.Args[0])
+	return []byte(value), ok
+}
+
+func (e *extractor) elements(expr ast.Expr) []ast.Expr {
+	if cl, ok := expr.(*ast.CompositeLit); ok {
+		return cl.Elts
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v3"
+)
+
+func TestExtract(t *testing.T) {
+	env.ClearRegister()
+	policy := env.DuplicateFieldPolicy
+	env.DuplicateFieldPolicy = env.DuplicatePanic
+	defer func() { env.DuplicateFieldPolicy = policy }()
+
+	diagnostics, err := extract("testdata/app", []string{"./..."})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`app.go:20: field name "app-name" must only contain capital letters, numbers or underscores`,
+		`app.go:21: field APP_PORT is already declared at app.go:13`,
+		`config.go:13: field APP_HOST is already declared at app.go:12`,
+	}, diagnostics)
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, env.Print(buffer, "short-bash"))
+	assert.Equal(t, `APP_HOST="localhost"
+APP_PORT="8080"
+APP_TIMEOUT="5s"
+APP_DEBUG="true"
+APP_ORIGINS="\"a\",\"b\""
+APP_SHIFTS="monday:\"9am\""
+APP_KEY="6162"
+APP_DYNAMIC=""
+APP_LEVEL="info"
+APP_RETRIES="3"
+APP_DELAY="2s"
+`, buffer.String())
+
+	buffer.Reset()
+	require.NoError(t, env.Print(buffer, "markdown"))
+	assert.Contains(t, buffer.String(), "| `APP_HOST` | String | `localhost` | no |  |  | Host to listen on. | app.go:12 |\n")
+	assert.Contains(t, buffer.String(), "| `APP_PORT` | Int | `8080` | yes | `80`, `8080` |  |  | app.go:13 |\n")
+	assert.Contains(t, buffer.String(), "| `APP_LEVEL` | String | `info` | no | `debug`, `info` |  | Log level. | config.go:10 |\n")
+	assert.Contains(t, buffer.String(), "| `APP_RETRIES` | Int | `3` | no |  |  |  | config.go:11 |\n")
+}
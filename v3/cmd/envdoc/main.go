@@ -0,0 +1,56 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command envdoc finds the declarations of environment fields in the source code of the given packages and prints
+// them in one of the print formats without building or running the program. Default values and options are only
+// picked up if they are given as constants or literals.
+//
+//	envdoc -format markdown ./... > CONFIGURATION.md
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/simia-tech/env/v3"
+)
+
+func main() {
+	format := flag.String("format", "markdown", "print the fields in the given format. format can be '"+strings.Join(env.Printers(), "', '")+"'")
+	flag.Parse()
+
+	patterns := flag.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	diagnostics, err := extract(".", patterns)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, diagnostic := range diagnostics {
+		log.Print(diagnostic)
+	}
+
+	// The fields should be printed with their default values, regardless of the environment of this command.
+	for _, info := range env.Fields() {
+		os.Unsetenv(info.Name)
+	}
+
+	if err := env.Print(os.Stdout, *format); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,22 @@
+package app
+
+import (
+	"time"
+
+	"github.com/simia-tech/env/v2"
+)
+
+const defaultHost = "localhost"
+
+var (
+	host    = env.String("APP_HOST", defaultHost, env.Description("Host to listen on."), env.Group("http"))
+	port    = env.Int("APP_PORT", 8080, env.Required(), env.AllowedValues("80", "8080"))
+	timeout = env.Duration("APP_TIMEOUT", 5*time.Second)
+	debug   = env.Bool("APP_DEBUG", true, env.Deprecated("use APP_LOG_LEVEL"))
+	origins = env.Strings("APP_ORIGINS", []string{"a", "b"})
+	shifts  = env.StringMap("APP_SHIFTS", map[string]string{"monday": "9am"})
+	key     = env.Bytes("APP_KEY", []byte("ab"), env.Sensitive())
+	dynamic = env.String("APP_DYNAMIC", time.Now().String())
+	invalid = env.String("app-name", "")
+	again   = env.Int("APP_PORT", 80)
+)
@@ -0,0 +1,14 @@
+package app
+
+import (
+	"time"
+
+	"github.com/simia-tech/env/v3"
+)
+
+var (
+	level   = env.Field("APP_LEVEL", "info", env.AllowedValues("debug", "info"), env.Description("Log level."))
+	retries = env.Field[int]("APP_RETRIES", 3, env.Tags("network"))
+	delay   = env.Field("APP_DELAY", 2*time.Second)
+	twice   = env.Field("APP_HOST", "127.0.0.1")
+)
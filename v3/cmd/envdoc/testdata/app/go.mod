@@ -0,0 +1,13 @@
+module app
+
+go 1.22
+
+require (
+	github.com/simia-tech/env/v2 v2.0.0
+	github.com/simia-tech/env/v3 v3.0.0
+)
+
+replace (
+	github.com/simia-tech/env/v2 => ../stub/v2
+	github.com/simia-tech/env/v3 => ../stub/v3
+)
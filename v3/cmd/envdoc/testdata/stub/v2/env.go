@@ -0,0 +1,21 @@
+package env
+
+import "time"
+
+type Option func()
+
+type Field struct{}
+
+func Bool(name string, defaultValue bool, opts ...Option) *Field                   { return nil }
+func Bytes(name string, defaultValue []byte, opts ...Option) *Field                { return nil }
+func Duration(name string, defaultValue time.Duration, opts ...Option) *Field      { return nil }
+func Int(name string, defaultValue int, opts ...Option) *Field                     { return nil }
+func Strings(name string, defaultValue []string, opts ...Option) *Field            { return nil }
+func String(name, defaultValue string, opts ...Option) *Field                      { return nil }
+func StringMap(name string, defaultValue map[string]string, opts ...Option) *Field { return nil }
+func AllowedValues(values ...string) Option                                        { return nil }
+func Deprecated(message string) Option                                             { return nil }
+func Description(text string) Option                                               { return nil }
+func Group(name string) Option                                                     { return nil }
+func Required() Option                                                             { return nil }
+func Sensitive() Option                                                            { return nil }
@@ -0,0 +1,3 @@
+module github.com/simia-tech/env/v2
+
+go 1.22
@@ -0,0 +1,3 @@
+module github.com/simia-tech/env/v3
+
+go 1.22
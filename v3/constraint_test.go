@@ -0,0 +1,73 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/simia-tech/env/v3"
+)
+
+func TestConstraints(t *testing.T) {
+	env.ClearRegister()
+	cert := env.Field("CONSTRAINT_TLS_CERT", "")
+	key := env.Field("CONSTRAINT_TLS_KEY", "")
+	password := env.Field("CONSTRAINT_PASSWORD", "")
+	passwordFile := env.Field("CONSTRAINT_PASSWORD_FILE", "", env.Aliases("CONSTRAINT_PASSWORD_PATH"))
+	mode := env.Field("CONSTRAINT_MODE", "plain")
+	env.RequireTogether(cert, key)
+	env.MutuallyExclusive(password, passwordFile)
+	env.RequiredIf(cert, func(s *env.Snapshot) bool {
+		return env.Value(s, mode) == "tls"
+	})
+
+	testFn := func(values map[string]string, expectErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			for name, value := range values {
+				t.Setenv(name, value)
+			}
+
+			err := env.Validate()
+			if expectErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, expectErr)
+			}
+		}
+	}
+
+	t.Run("Empty", testFn(map[string]string{}, nil))
+	t.Run("Together", testFn(map[string]string{"CONSTRAINT_TLS_CERT": "cert", "CONSTRAINT_TLS_KEY": "key"}, nil))
+	t.Run("NotTogether", testFn(map[string]string{"CONSTRAINT_TLS_CERT": "cert"}, env.ErrMissingValue))
+	t.Run("Exclusive", testFn(map[string]string{"CONSTRAINT_PASSWORD": "secret"}, nil))
+	t.Run("NotExclusive", testFn(map[string]string{"CONSTRAINT_PASSWORD": "secret", "CONSTRAINT_PASSWORD_FILE": "file"}, env.ErrMutuallyExclusive))
+	t.Run("NotExclusiveAlias", testFn(map[string]string{"CONSTRAINT_PASSWORD": "secret", "CONSTRAINT_PASSWORD_PATH": "file"}, env.ErrMutuallyExclusive))
+	t.Run("RequiredIf", testFn(map[string]string{"CONSTRAINT_MODE": "tls", "CONSTRAINT_TLS_CERT": "cert", "CONSTRAINT_TLS_KEY": "key"}, nil))
+	t.Run("RequiredIfMissing", testFn(map[string]string{"CONSTRAINT_MODE": "tls"}, env.ErrMissingValue))
+	t.Run("Load", func(t *testing.T) {
+		t.Setenv("CONSTRAINT_TLS_CERT", "cert")
+		defer env.Unload()
+		_, err := env.Load()
+		assert.ErrorIs(t, err, env.ErrMissingValue)
+	})
+
+	assert.Regexp(t, `^String field. Must be set together with 'CONSTRAINT_TLS_KEY'. Required under a condition. `, cert.Description())
+	assert.Regexp(t, `^String field. Must not be set together with 'CONSTRAINT_PASSWORD_FILE'. `, password.Description())
+	assert.Regexp(t, `^String field. The default value is 'plain'. `, mode.Description())
+	info, _ := env.Lookup("CONSTRAINT_TLS_CERT")
+	assert.Equal(t, []string{"Must be set together with 'CONSTRAINT_TLS_KEY'.", "Required under a condition."}, info.Constraints)
+}
@@ -0,0 +1,63 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import "context"
+
+type contextKey struct{}
+
+// WithSource returns a copy of ctx, that carries the provided source. The values of the sources in a context take
+// precedence over all other values, where the source added last is consulted first. They are only read by the
+// methods taking a context, like GetContext, so they are scoped to the code that receives the context.
+func WithSource(ctx context.Context, source Source) context.Context {
+	parents, _ := ctx.Value(contextKey{}).([]Source)
+	return context.WithValue(ctx, contextKey{}, append([]Source{source}, parents...))
+}
+
+func contextLookup(ctx context.Context) (lookupFunc, bool) {
+	sources, ok := ctx.Value(contextKey{}).([]Source)
+	if !ok {
+		return nil, false
+	}
+	lookup := currentLookup()
+	return func(name string) (string, string, bool) {
+		for _, source := range sources {
+			if value, ok := source.Lookup(name); ok {
+				return value, sourceName(source), true
+			}
+		}
+		return lookup(name)
+	}, true
+}
+
+// GetContext returns the field's value like Get, but the sources carried by the context take precedence. See
+// WithSource.
+func (f *F[T]) GetContext(ctx context.Context) (T, error) {
+	lookup, ok := contextLookup(ctx)
+	if !ok {
+		return f.Get()
+	}
+	return f.get(lookup)
+}
+
+// GetOrDefaultContext returns the field's value like GetOrDefault, but the sources carried by the context take
+// precedence. See WithSource.
+func (f *F[T]) GetOrDefaultContext(ctx context.Context) T {
+	value, err := f.GetContext(ctx)
+	if err != nil {
+		handleError(&f.options, err)
+	}
+	return value
+}
@@ -0,0 +1,344 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package envcheck implements an analyzer that checks the declarations of environment fields.
+package envcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const (
+	envPathV2 = "github.com/simia-tech/env/v2"
+	envPathV3 = "github.com/simia-tech/env/v3"
+)
+
+var (
+	constructors = map[string]bool{
+		"Bool":      true,
+		"Bytes":     true,
+		"Duration":  true,
+		"Int":       true,
+		"Ints":      true,
+		"String":    true,
+		"Strings":   true,
+		"StringMap": true,
+	}
+	nameRegexp = regexp.MustCompile("^[A-Z0-9_]+$")
+)
+
+// Analyzer reports invalid field names, duplicate field names across packages, allowed values that exclude the
+// default value and fields that are declared inside a function.
+var Analyzer = &analysis.Analyzer{
+	Name:      "envcheck",
+	Doc:       "check declarations of environment fields",
+	Run:       run,
+	FactTypes: []analysis.Fact{new(declaredFields)},
+}
+
+// declaredFields is a package fact that maps the names of the fields declared in a package to their positions.
+type declaredFields struct {
+	Positions map[string]string
+}
+
+func (*declaredFields) AFact() {}
+
+func (df *declaredFields) String() string {
+	names := make([]string, 0, len(df.Positions))
+	for name := range df.Positions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return "declaredFields(" + strings.Join(names, ", ") + ")"
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	imported := importedFields(pass)
+
+	declared := &declaredFields{Positions: map[string]string{}}
+	for _, file := range pass.Files {
+		filename := pass.Fset.File(file.Pos()).Name()
+		if strings.HasSuffix(filename, "_test.go") {
+			continue
+		}
+		inspect(file, func(call *ast.CallExpr, inFunction bool) {
+			constructor := fieldConstructor(pass, call)
+			if constructor == "" || len(call.Args) < 2 {
+				return
+			}
+
+			if inFunction {
+				pass.Reportf(call.Pos(), "field should be declared at package level, not inside a function")
+			}
+
+			name, ok := constantString(pass, call.Args[0])
+			if !ok {
+				return
+			}
+			position := pass.Fset.Position(call.Pos()).String()
+			if !nameRegexp.MatchString(name) {
+				pass.Reportf(call.Pos(), "field name %q must only contain capital letters, numbers or underscores", name)
+			}
+			if other, ok := declared.Positions[name]; ok {
+				pass.Reportf(call.Pos(), "field %s is already declared at %s", name, other)
+			} else if other, ok := imported[name]; ok {
+				pass.Reportf(call.Pos(), "field %s is already declared at %s", name, other)
+			} else {
+				declared.Positions[name] = position
+			}
+
+			if defaultValue, ok := constantDefault(pass, constructor, call.Args[1]); ok {
+				for _, opt := range call.Args[2:] {
+					values, ok := allowedValues(pass, opt)
+					if ok && !contains(values, defaultValue) {
+						pass.Reportf(opt.Pos(), "allowed values of field %s exclude the default value %q", name, defaultValue)
+					}
+				}
+			}
+		})
+	}
+
+	if len(declared.Positions) > 0 {
+		pass.ExportPackageFact(declared)
+	}
+	return nil, nil
+}
+
+// importedFields merges the fields declared in the imported packages and reports the names, that are declared in
+// more than one of them. A collision is only reported by the first package that imports both declarations via
+// different imports, since the packages in between have reported it already.
+func importedFields(pass *analysis.Pass) map[string]string {
+	facts := pass.AllPackageFacts()
+	sort.Slice(facts, func(i, j int) bool { return facts[i].Package.Path() < facts[j].Package.Path() })
+
+	imported := map[string]string{}
+	declaringPackage := map[string]*types.Package{}
+	for _, fact := range facts {
+		df, ok := fact.Fact.(*declaredFields)
+		if !ok || fact.Package == pass.Pkg {
+			continue
+		}
+		names := make([]string, 0, len(df.Positions))
+		for name := range df.Positions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			other, ok := declaringPackage[name]
+			if !ok {
+				imported[name] = df.Positions[name]
+				declaringPackage[name] = fact.Package
+				continue
+			}
+			if spec := importSpec(pass, other, fact.Package); spec != nil {
+				pass.Reportf(spec.Pos(), "field %s is declared at %s and at %s", name, imported[name], df.Positions[name])
+			}
+		}
+	}
+	return imported
+}
+
+// importSpec returns the import of the analyzed package, that leads to b, if a and b aren't both reached via the
+// same import. Otherwise, nil is returned.
+func importSpec(pass *analysis.Pass, a, b *types.Package) *ast.ImportSpec {
+	for _, imp := range pass.Pkg.Imports() {
+		deps := dependencies(imp, map[*types.Package]bool{})
+		if deps[a] && deps[b] {
+			return nil
+		}
+	}
+	for _, file := range pass.Files {
+		for _, spec := range file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			for _, imp := range pass.Pkg.Imports() {
+				if imp.Path() == path && dependencies(imp, map[*types.Package]bool{})[b] {
+					return spec
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// dependencies adds the provided package and all packages it imports directly or indirectly to the provided set.
+func dependencies(pkg *types.Package, set map[*types.Package]bool) map[*types.Package]bool {
+	if set[pkg] {
+		return set
+	}
+	set[pkg] = true
+	for _, imp := range pkg.Imports() {
+		dependencies(imp, set)
+	}
+	return set
+}
+
+func inspect(file *ast.File, fn func(*ast.CallExpr, bool)) {
+	for _, decl := range file.Decls {
+		inFunction := false
+		if fd, ok := decl.(*ast.FuncDecl); ok {
+			inFunction = fd.Name.Name != "init" || fd.Recv != nil
+		}
+		ast.Inspect(decl, func(node ast.Node) bool {
+			switch n := node.(type) {
+			case *ast.FuncLit:
+				if !inFunction {
+					ast.Inspect(n.Body, func(node ast.Node) bool {
+						if call, ok := node.(*ast.CallExpr); ok {
+							fn(call, true)
+						}
+						return true
+					})
+					return false
+				}
+			case *ast.CallExpr:
+				fn(n, inFunction)
+			}
+			return true
+		})
+	}
+}
+
+// fieldConstructor returns the name of the version 2 constructor, that is called to declare a field, e.g.
+// "String" for `env.String(...)`. For the version 3 function `env.Field`, the constructor is derived from the type
+// argument of the instantiation, so `env.Field("NAME", "")` and `env.Field[int]("PORT", 80)` are treated like
+// `env.String` and `env.Int`. If the call doesn't declare a field, an empty string is returned.
+func fieldConstructor(pass *analysis.Pass, call *ast.CallExpr) string {
+	fn, ident := calledFunction(pass, call)
+	if fn == nil {
+		return ""
+	}
+	switch fn.Pkg().Path() {
+	case envPathV2:
+		if constructors[fn.Name()] {
+			return fn.Name()
+		}
+	case envPathV3:
+		if fn.Name() == "Field" {
+			return typeArgumentConstructor(pass, ident)
+		}
+	}
+	return ""
+}
+
+// typeArgumentConstructor returns the constructor, that matches the type argument of the instantiated function.
+// Type arguments without a matching constructor, like `time.Duration`, yield "Field", so the declaration is
+// checked, but not its default value.
+func typeArgumentConstructor(pass *analysis.Pass, ident *ast.Ident) string {
+	instance, ok := pass.TypesInfo.Instances[ident]
+	if !ok || instance.TypeArgs.Len() != 1 {
+		return ""
+	}
+	if basic, ok := instance.TypeArgs.At(0).(*types.Basic); ok {
+		switch basic.Kind() {
+		case types.Bool:
+			return "Bool"
+		case types.Int:
+			return "Int"
+		case types.String:
+			return "String"
+		}
+	}
+	return "Field"
+}
+
+// calledFunction returns the package level function of the env package, that is called, together with the
+// identifier that refers to it. Explicit instantiations like `env.Field[int]` are unwrapped.
+func calledFunction(pass *analysis.Pass, call *ast.CallExpr) (*types.Func, *ast.Ident) {
+	fun := call.Fun
+	switch index := fun.(type) {
+	case *ast.IndexExpr:
+		fun = index.X
+	case *ast.IndexListExpr:
+		fun = index.X
+	}
+	var ident *ast.Ident
+	switch f := fun.(type) {
+	case *ast.SelectorExpr:
+		ident = f.Sel
+	case *ast.Ident:
+		ident = f
+	default:
+		return nil, nil
+	}
+	fn, ok := pass.TypesInfo.Uses[ident].(*types.Func)
+	if !ok || fn.Pkg() == nil || (fn.Pkg().Path() != envPathV2 && fn.Pkg().Path() != envPathV3) {
+		return nil, nil
+	}
+	if sig, ok := fn.Type().(*types.Signature); !ok || sig.Recv() != nil {
+		return nil, nil
+	}
+	return fn, ident
+}
+
+func constantString(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+func constantDefault(pass *analysis.Pass, constructor string, expr ast.Expr) (string, bool) {
+	switch constructor {
+	case "String":
+		return constantString(pass, expr)
+	case "Int":
+		tv, ok := pass.TypesInfo.Types[expr]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.Int {
+			return "", false
+		}
+		value, ok := constant.Int64Val(tv.Value)
+		return strconv.FormatInt(value, 10), ok
+	}
+	return "", false
+}
+
+func allowedValues(pass *analysis.Pass, expr ast.Expr) ([]string, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || call.Ellipsis.IsValid() {
+		return nil, false
+	}
+	if fn, _ := calledFunction(pass, call); fn == nil || fn.Name() != "AllowedValues" {
+		return nil, false
+	}
+	values := []string{}
+	for _, arg := range call.Args {
+		value, ok := constantString(pass, arg)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, value)
+	}
+	return values, true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package app // want package:"declaredFields\\(LAZY\\)"
+
+import (
+	_ "config"
+
+	"github.com/simia-tech/env/v2"
+)
+
+var port = env.Int("PORT", 9090) // want `field PORT is already declared at .+config.go:7:\d+`
+
+var handler = func() {
+	env.String("LAZY", "") // want `field should be declared at package level, not inside a function`
+}
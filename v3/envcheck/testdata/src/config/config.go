@@ -0,0 +1,22 @@
+package config // want package:"declaredFields\\(HOST, MODE, PORT, REQUEST_ID, TOKEN, USER, app-name\\)"
+
+import "github.com/simia-tech/env/v2"
+
+var (
+	Host  = env.String("HOST", "localhost")
+	Port  = env.Int("PORT", 8080, env.AllowedValues("80", "8080"))
+	Mode  = env.String("MODE", "debug", env.AllowedValues("release", "test")) // want `allowed values of field MODE exclude the default value "debug"`
+	Name  = env.String("app-name", "")                                        // want `field name "app-name" must only contain capital letters, numbers or underscores`
+	User  = env.String("USER", "")
+	Again = env.String("USER", "") // want `field USER is already declared at .+config.go:10:\d+`
+)
+
+var token *env.StringField
+
+func init() {
+	token = env.String("TOKEN", "")
+}
+
+func Handle() {
+	env.String("REQUEST_ID", "") // want `field should be declared at package level, not inside a function`
+}
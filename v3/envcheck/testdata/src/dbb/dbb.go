@@ -0,0 +1,5 @@
+package dbb // want package:"declaredFields\\(DB_HOST\\)"
+
+import "github.com/simia-tech/env/v2"
+
+var Host = env.String("DB_HOST", "localhost")
@@ -0,0 +1,6 @@
+package frontend
+
+import (
+	_ "dba"
+	_ "service"
+)
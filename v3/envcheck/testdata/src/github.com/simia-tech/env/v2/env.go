@@ -0,0 +1,13 @@
+package env
+
+type Option func()
+
+type StringField struct{}
+
+type IntField struct{}
+
+func String(name, defaultValue string, opts ...Option) *StringField { return nil }
+
+func Int(name string, defaultValue int, opts ...Option) *IntField { return nil }
+
+func AllowedValues(values ...string) Option { return nil }
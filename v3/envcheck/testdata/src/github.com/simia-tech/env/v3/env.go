@@ -0,0 +1,9 @@
+package env
+
+type Option func()
+
+type F[T any] struct{}
+
+func Field[T any](name string, defaultValue T, opts ...Option) *F[T] { return nil }
+
+func AllowedValues(values ...string) Option { return nil }
@@ -0,0 +1,6 @@
+package service
+
+import (
+	_ "dba"
+	_ "dbb" // want `field DB_HOST is declared at .+dba.go:5:\d+ and at .+dbb.go:5:\d+`
+)
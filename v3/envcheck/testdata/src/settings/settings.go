@@ -0,0 +1,23 @@
+package settings // want package:"declaredFields\\(LEVEL, RETRIES, SCOPED, TIMEOUT, VERBOSE, app_mode\\)"
+
+import (
+	"time"
+
+	_ "config"
+
+	"github.com/simia-tech/env/v3"
+)
+
+var (
+	Level   = env.Field("LEVEL", "info", env.AllowedValues("debug", "warn")) // want `allowed values of field LEVEL exclude the default value "info"`
+	Retries = env.Field[int]("RETRIES", 3, env.AllowedValues("3", "5"))
+	Timeout = env.Field("TIMEOUT", 5*time.Second, env.AllowedValues("5s"))
+	Verbose = env.Field("VERBOSE", false)
+	Mode    = env.Field("app_mode", "")    // want `field name "app_mode" must only contain capital letters, numbers or underscores`
+	Port    = env.Field("PORT", 80)        // want `field PORT is already declared at .+config.go:7:\d+`
+	Limit   = env.Field[int]("RETRIES", 5) // want `field RETRIES is already declared at .+settings.go:13:\d+`
+)
+
+func Handle() {
+	env.Field("SCOPED", 0) // want `field should be declared at package level, not inside a function`
+}
@@ -0,0 +1,98 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package envcli integrates the environment fields with command-line interfaces that are based on
+// github.com/spf13/pflag or github.com/spf13/cobra.
+package envcli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/simia-tech/env/v3"
+)
+
+// Flags holds the values of the flags --print-env, --print-env-format and --check-env.
+type Flags struct {
+	printEnv       bool
+	printEnvFormat string
+	checkEnv       bool
+	opts           []env.PrintOption
+}
+
+// AddFlags adds the flags --print-env, --print-env-format and --check-env to the provided flag set. Additionally,
+// a flag is added for each field with the option Flag or AutoFlag (see env.BindFlags). The printed and bound
+// fields can be selected by the provided options.
+func AddFlags(fs *pflag.FlagSet, opts ...env.PrintOption) *Flags {
+	f := &Flags{opts: opts}
+	fs.BoolVar(&f.printEnv, "print-env", false, "print the environment with the current values")
+	fs.StringVar(&f.printEnvFormat, "print-env-format", "short-bash", "print the environment in the given format. format can be "+joinFormats(env.Printers()))
+	fs.BoolVar(&f.checkEnv, "check-env", false, "check the environment for invalid values and violated constraints")
+
+	gfs := flag.NewFlagSet("", flag.ContinueOnError)
+	env.BindFlags(gfs, opts...)
+	fs.AddGoFlagSet(gfs)
+
+	return f
+}
+
+// Handle performs the action of the given flags after the flag set has been parsed. If --print-env was given, the
+// environment is printed to the provided writer. If --check-env was given, the environment is validated. In both
+// cases, true is returned together with a possible error.
+func (f *Flags) Handle(w io.Writer) (bool, error) {
+	if f.checkEnv {
+		if err := env.Validate(); err != nil {
+			return true, err
+		}
+		_, err := fmt.Fprintln(w, "environment is valid")
+		return true, err
+	}
+	if f.printEnv {
+		return true, env.Print(w, f.printEnvFormat, f.opts...)
+	}
+	return false, nil
+}
+
+// Attach adds the flags of AddFlags to the local flag set of the provided command and wraps its run function, so
+// --print-env and --check-env are handled before the command is run. Since the flags are local, each subcommand
+// can be attached with options that select its own fields, e.g. env.PrintGroup("database").
+func Attach(cmd *cobra.Command, opts ...env.PrintOption) {
+	f := AddFlags(cmd.Flags(), opts...)
+
+	run, runE := cmd.Run, cmd.RunE
+	cmd.Run = nil
+	cmd.RunE = func(c *cobra.Command, args []string) error {
+		if handled, err := f.Handle(c.OutOrStdout()); handled {
+			return err
+		}
+		switch {
+		case runE != nil:
+			return runE(c, args)
+		case run != nil:
+			run(c, args)
+			return nil
+		default:
+			return c.Help()
+		}
+	}
+}
+
+func joinFormats(formats []string) string {
+	return "'" + strings.Join(formats, "', '") + "'"
+}
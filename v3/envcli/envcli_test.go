@@ -0,0 +1,70 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envcli_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v3"
+	"github.com/simia-tech/env/v3/envcli"
+)
+
+func TestAttach(t *testing.T) {
+	env.ClearRegister()
+	defer env.ClearRegister()
+	env.Field("ENVCLI_NAME", "joe", env.AutoFlag(), env.Group("app"))
+	dbURL := env.Field("ENVCLI_DB_URL", "postgres://localhost", env.AutoFlag(), env.Group("db"))
+
+	testFn := func(args []string, expectOutput string) func(*testing.T) {
+		return func(t *testing.T) {
+			ran := ""
+			root := &cobra.Command{Use: "root"}
+			migrate := &cobra.Command{
+				Use: "migrate",
+				Run: func(*cobra.Command, []string) {
+					ran = dbURL.GetOrDefault()
+				},
+			}
+			root.AddCommand(migrate)
+			envcli.Attach(root)
+			envcli.Attach(migrate, env.PrintGroup("db"))
+
+			buffer := &bytes.Buffer{}
+			root.SetOut(buffer)
+			root.SetArgs(args)
+			require.NoError(t, root.Execute())
+			assert.Equal(t, expectOutput, buffer.String()+ran)
+		}
+	}
+
+	t.Run("PrintAll", testFn([]string{"--print-env"}, "ENVCLI_NAME=\"joe\"\nENVCLI_DB_URL=\"postgres://localhost\"\n"))
+	t.Run("PrintGroup", testFn([]string{"migrate", "--print-env"}, "ENVCLI_DB_URL=\"postgres://localhost\"\n"))
+	t.Run("Check", testFn([]string{"migrate", "--check-env"}, "environment is valid\n"))
+	t.Run("FieldFlag", testFn([]string{"migrate", "--envcli-db-url", "postgres://db"}, "postgres://db"))
+	t.Run("OwnGroupOnly", func(t *testing.T) {
+		root := &cobra.Command{Use: "root"}
+		migrate := &cobra.Command{Use: "migrate", Run: func(*cobra.Command, []string) {}}
+		root.AddCommand(migrate)
+		envcli.Attach(migrate, env.PrintGroup("db"))
+
+		assert.NotNil(t, migrate.Flags().Lookup("envcli-db-url"))
+		assert.Nil(t, migrate.Flags().Lookup("envcli-name"))
+	})
+}
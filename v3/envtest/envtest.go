@@ -0,0 +1,59 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package envtest provides helpers to override environment fields in tests without modifying the environment of
+// the process.
+//
+// The overrides are carried by a context, so they are only visible to code that receives the context and reads the
+// fields via GetContext or GetOrDefaultContext. Tests using them can call t.Parallel. Overrides can be nested: an
+// override in a derived context takes precedence, while the parent context keeps its values.
+package envtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/simia-tech/env/v3"
+)
+
+// Override returns a copy of ctx, in which the provided field holds the provided raw value. If the value is not
+// valid for the field, the test fails.
+func Override[T env.FieldType](t testing.TB, ctx context.Context, field *env.F[T], value string) context.Context {
+	t.Helper()
+	ctx = WithValues(ctx, map[string]string{field.Name(): value})
+	if _, err := field.GetContext(ctx); err != nil {
+		t.Fatalf("override: %v", err)
+	}
+	return ctx
+}
+
+// WithValues returns a copy of ctx, in which the named fields hold the provided raw values.
+func WithValues(ctx context.Context, values map[string]string) context.Context {
+	o := overrides{}
+	for name, value := range values {
+		o[name] = value
+	}
+	return env.WithSource(ctx, o)
+}
+
+type overrides map[string]string
+
+func (o overrides) Lookup(name string) (string, bool) {
+	value, ok := o[name]
+	return value, ok
+}
+
+func (o overrides) String() string {
+	return "override"
+}
@@ -0,0 +1,68 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package envtest_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/simia-tech/env/v3"
+	"github.com/simia-tech/env/v3/envtest"
+)
+
+var (
+	name = env.Field("ENVTEST_NAME", "joe")
+	age  = env.Field("ENVTEST_AGE", 24)
+)
+
+func TestOverride(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Name", func(t *testing.T) {
+		t.Parallel()
+		ctx := envtest.Override(t, ctx, name, "jane")
+		assert.Equal(t, "jane", name.GetOrDefaultContext(ctx))
+		_, ok := os.LookupEnv("ENVTEST_NAME")
+		assert.False(t, ok)
+	})
+	t.Run("SameField", func(t *testing.T) {
+		t.Parallel()
+		ctx := envtest.Override(t, ctx, name, "jim")
+		assert.Equal(t, "jim", name.GetOrDefaultContext(ctx))
+	})
+	t.Run("Nested", func(t *testing.T) {
+		t.Parallel()
+		ctx := envtest.Override(t, ctx, age, "42")
+		t.Run("Override", func(t *testing.T) {
+			ctx := envtest.Override(t, ctx, age, "43")
+			assert.Equal(t, 43, age.GetOrDefaultContext(ctx))
+		})
+		assert.Equal(t, 42, age.GetOrDefaultContext(ctx))
+		assert.Equal(t, 24, age.GetOrDefault())
+	})
+}
+
+func TestWithValues(t *testing.T) {
+	t.Parallel()
+	ctx := envtest.WithValues(context.Background(), map[string]string{"ENVTEST_NAME": "jane", "ENVTEST_AGE": "42"})
+	assert.Equal(t, "jane", name.GetOrDefaultContext(ctx))
+	assert.Equal(t, 42, age.GetOrDefaultContext(ctx))
+
+	info, _ := env.Lookup("ENVTEST_NAME")
+	assert.Equal(t, "joe", info.Value)
+}
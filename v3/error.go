@@ -0,0 +1,167 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+var (
+	ErrMissingValue   = errors.New("missing value")
+	ErrInvalidValue   = errors.New("invalid value")
+	ErrDuplicateField = errors.New("duplicate field")
+
+	ErrUndefinedReference    = errors.New("undefined reference")
+	ErrUnterminatedReference = errors.New("unterminated reference")
+	ErrReferenceCycle        = errors.New("reference cycle")
+
+	ErrConflictingValues = errors.New("conflicting values")
+	ErrDeprecated        = errors.New("deprecated")
+	ErrMutuallyExclusive = errors.New("mutually exclusive")
+)
+
+// FieldError describes an error in the value of a field.
+type FieldError struct {
+	Name     string
+	Raw      string
+	Location string
+	Source   string
+	Cause    error
+}
+
+// FieldErrorMessage returns the message of a FieldError. It can be replaced to localize the messages.
+var FieldErrorMessage = func(e *FieldError) string {
+	if e.Raw == "" {
+		return fmt.Sprintf("field [%s]: %v", e.Name, e.Cause)
+	}
+	return fmt.Sprintf("field [%s]: value [%s]: %v", e.Name, e.Raw, e.Cause)
+}
+
+func (e *FieldError) Error() string {
+	return FieldErrorMessage(e)
+}
+
+// Unwrap returns the cause of the error.
+func (e *FieldError) Unwrap() error {
+	return e.Cause
+}
+
+// ParseError describes an error at a position in a raw value.
+type ParseError struct {
+	Raw   string
+	Index int
+	Cause error
+}
+
+// ParseErrorMessage returns the message of a ParseError. It can be replaced to localize the messages.
+var ParseErrorMessage = func(e *ParseError) string {
+	return fmt.Sprintf("at index %d: %v", e.Index, e.Cause)
+}
+
+func (e *ParseError) Error() string {
+	return ParseErrorMessage(e)
+}
+
+// Unwrap returns the cause of the error.
+func (e *ParseError) Unwrap() error {
+	return e.Cause
+}
+
+// Caret returns the raw value and a second line with a caret under the character at the error's index.
+func (e *ParseError) Caret() string {
+	index := e.Index
+	if index > len(e.Raw) {
+		index = len(e.Raw)
+	}
+	return e.Raw + "\n" + strings.Repeat(" ", utf8.RuneCountInString(e.Raw[:index])) + "^"
+}
+
+// ErrorHandler defines the handler for the errors, that are reported by GetOrDefault and GetRawOrDefault. By
+// default, StderrErrorHandler is set.
+var ErrorHandler = StderrErrorHandler
+
+// WarningHandler defines a handler for warnings, e.g. a field that is declared twice with different definitions.
+// By default, StderrErrorHandler is set.
+var WarningHandler = StderrErrorHandler
+
+var warned sync.Map
+
+// warnOnce passes the error to the WarningHandler, if no warning with the same key has been passed before.
+func warnOnce(key string, err error) {
+	if _, loaded := warned.LoadOrStore(key, true); !loaded {
+		WarningHandler(err)
+	}
+}
+
+func clearWarnings() {
+	warned.Range(func(key, _ any) bool {
+		warned.Delete(key)
+		return true
+	})
+}
+
+// Policy defines how GetOrDefault and GetRawOrDefault handle an error of a field.
+type Policy int
+
+// Implementations of different policies.
+const (
+	// PolicyWarn reports the error to the field's error handler or the ErrorHandler and returns the default value.
+	PolicyWarn Policy = iota
+	// PolicyIgnore returns the default value without reporting the error.
+	PolicyIgnore
+	// PolicyPanic panics with the error.
+	PolicyPanic
+)
+
+// ErrorPolicy defines the policy for all fields without the option FailurePolicy. By default, PolicyWarn is set.
+var ErrorPolicy = PolicyWarn
+
+// Implementations of different error handlers.
+var (
+	NullErrorHandler   = func(error) {}
+	StdoutErrorHandler = func(err error) {
+		fmt.Fprintln(os.Stdout, err)
+	}
+	StderrErrorHandler = func(err error) {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	LogErrorHandler = func(err error) {
+		log.Println(err)
+	}
+)
+
+func handleError(o *options, err error) {
+	policy := ErrorPolicy
+	if o.policy != nil {
+		policy = *o.policy
+	}
+	switch policy {
+	case PolicyIgnore:
+	case PolicyPanic:
+		panic(err)
+	default:
+		if o.errorHandler != nil {
+			o.errorHandler(err)
+		} else {
+			ErrorHandler(err)
+		}
+	}
+}
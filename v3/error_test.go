@@ -0,0 +1,112 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v3"
+)
+
+func TestErrorPolicy(t *testing.T) {
+	env.ClearRegister()
+	global := env.Field("POLICY_GLOBAL", 1)
+	ignore := env.Field("POLICY_IGNORE", 2, env.FailurePolicy(env.PolicyIgnore))
+	panics := env.Field("POLICY_PANIC", 3, env.FailurePolicy(env.PolicyPanic))
+	fieldErrors := []error{}
+	handled := env.Field("POLICY_HANDLED", 4, env.OnError(func(err error) {
+		fieldErrors = append(fieldErrors, err)
+	}))
+	allowed := env.Field("POLICY_ALLOWED", "one", env.AllowedValues("one", "two"))
+	t.Setenv("POLICY_GLOBAL", "a")
+	t.Setenv("POLICY_IGNORE", "b")
+	t.Setenv("POLICY_PANIC", "c")
+	t.Setenv("POLICY_HANDLED", "d")
+	t.Setenv("POLICY_ALLOWED", "three")
+
+	globalErrors := []error{}
+	eh, ep := env.ErrorHandler, env.ErrorPolicy
+	env.ErrorHandler = func(err error) {
+		globalErrors = append(globalErrors, err)
+	}
+	defer func() {
+		env.ErrorHandler, env.ErrorPolicy = eh, ep
+	}()
+
+	assert.Equal(t, 1, global.GetOrDefault())
+	assert.Equal(t, 2, ignore.GetOrDefault())
+	assert.Panics(t, func() { panics.GetOrDefault() })
+	assert.Equal(t, 4, handled.GetOrDefault())
+	assert.Equal(t, "one", allowed.GetRawOrDefault())
+	assert.Len(t, globalErrors, 2)
+	assert.ErrorIs(t, globalErrors[1], env.ErrInvalidValue)
+	assert.Len(t, fieldErrors, 1)
+
+	env.ErrorPolicy = env.PolicyPanic
+	assert.Panics(t, func() { global.GetOrDefault() })
+	assert.Equal(t, 2, ignore.GetOrDefault())
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, env.Print(buffer, "short-bash"))
+	assert.Len(t, globalErrors, 2)
+	assert.Len(t, fieldErrors, 1)
+}
+
+func TestFieldError(t *testing.T) {
+	env.ClearRegister()
+	shifts := env.Field[map[string]string]("ERROR_SHIFTS", nil)
+	port := env.Field("ERROR_PORT", 8080, env.Required())
+
+	t.Run("Parse", func(t *testing.T) {
+		t.Setenv("ERROR_SHIFTS", `monday:"9am"x`)
+
+		_, err := shifts.Get()
+		fieldErr := (*env.FieldError)(nil)
+		require.True(t, errors.As(err, &fieldErr))
+		assert.Equal(t, "ERROR_SHIFTS", fieldErr.Name)
+		assert.Equal(t, `monday:"9am"x`, fieldErr.Raw)
+		assert.Regexp(t, `error_test\.go:\d+$`, fieldErr.Location)
+		assert.Equal(t, "environment", fieldErr.Source)
+
+		parseErr := (*env.ParseError)(nil)
+		require.True(t, errors.As(err, &parseErr))
+		assert.Equal(t, 12, parseErr.Index)
+		assert.Equal(t, "monday:\"9am\"x\n            ^", parseErr.Caret())
+		assert.EqualError(t, err, `field [ERROR_SHIFTS]: value [monday:"9am"x]: at index 12: unexpected rune`)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, err := port.Get()
+		assert.ErrorIs(t, err, env.ErrMissingValue)
+		assert.EqualError(t, err, "field [ERROR_PORT]: missing value")
+	})
+
+	t.Run("Localized", func(t *testing.T) {
+		message := env.FieldErrorMessage
+		env.FieldErrorMessage = func(e *env.FieldError) string {
+			return "Feld " + e.Name + " hat einen ungültigen Wert"
+		}
+		defer func() { env.FieldErrorMessage = message }()
+
+		t.Setenv("ERROR_PORT", "abc")
+		_, err := port.Get()
+		assert.EqualError(t, err, "Feld ERROR_PORT hat einen ungültigen Wert")
+	})
+}
@@ -0,0 +1,95 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v3"
+)
+
+func TestExpand(t *testing.T) {
+	env.ClearRegister()
+	env.Field("EXPAND_USER", "")
+	env.Field("EXPAND_HOST", "localhost")
+	env.Field("EXPAND_PORT", 5432)
+	env.Field("EXPAND_CYCLE_ONE", "", env.Expand())
+	env.Field("EXPAND_CYCLE_TWO", "", env.Expand())
+	env.Field("EXPAND_DSN", "postgres://${EXPAND_HOST}:${EXPAND_PORT}/app", env.Expand())
+	url := env.Field("EXPAND_URL", "", env.Expand())
+
+	path := filepath.Join(t.TempDir(), "app.env")
+	require.NoError(t, os.WriteFile(path, []byte("EXPAND_FILE=file\n"), 0o600))
+	source, err := env.NewFileSource(path)
+	require.NoError(t, err)
+	env.AddSource(source)
+
+	testFn := func(values map[string]string, expectValue string, expectErr error) func(*testing.T) {
+		return func(t *testing.T) {
+			for name, value := range values {
+				t.Setenv(name, value)
+			}
+
+			value, err := url.Get()
+			if expectErr == nil {
+				require.NoError(t, err)
+				assert.Equal(t, expectValue, value)
+			} else {
+				assert.ErrorIs(t, err, expectErr)
+			}
+		}
+	}
+
+	t.Run("Plain", testFn(map[string]string{"EXPAND_URL": "postgres://db"}, "postgres://db", nil))
+	t.Run("Fields", testFn(map[string]string{
+		"EXPAND_URL":  "postgres://${EXPAND_USER}@${EXPAND_HOST}:${EXPAND_PORT}/app",
+		"EXPAND_USER": "joe",
+	}, "postgres://joe@localhost:5432/app", nil))
+	t.Run("Environment", testFn(map[string]string{
+		"EXPAND_URL":   "postgres://${EXPAND_OTHER}/app",
+		"EXPAND_OTHER": "db",
+	}, "postgres://db/app", nil))
+	t.Run("Source", testFn(map[string]string{"EXPAND_URL": "${EXPAND_FILE}"}, "file", nil))
+	t.Run("Default", testFn(map[string]string{"EXPAND_URL": "postgres://${EXPAND_USER:-admin}@db"}, "postgres://admin@db", nil))
+	t.Run("NestedDefault", testFn(map[string]string{"EXPAND_URL": "postgres://${EXPAND_OTHER:-${EXPAND_HOST}}/app"}, "postgres://localhost/app", nil))
+	t.Run("NestedUndefined", testFn(map[string]string{"EXPAND_URL": "${EXPAND_OTHER:-${EXPAND_MISSING}}"}, "", env.ErrUndefinedReference))
+	t.Run("NestedUnterminated", testFn(map[string]string{"EXPAND_URL": "${EXPAND_OTHER:-${EXPAND_HOST}"}, "", env.ErrUnterminatedReference))
+	t.Run("ReferenceToExpandedDefault", testFn(map[string]string{"EXPAND_URL": "${EXPAND_DSN}?ssl=on"}, "postgres://localhost:5432/app?ssl=on", nil))
+	t.Run("Undefined", testFn(map[string]string{"EXPAND_URL": "postgres://${EXPAND_OTHER}/app"}, "", env.ErrUndefinedReference))
+	t.Run("Unterminated", testFn(map[string]string{"EXPAND_URL": "postgres://${EXPAND_HOST"}, "", env.ErrUnterminatedReference))
+	t.Run("Cycle", testFn(map[string]string{
+		"EXPAND_URL":       "${EXPAND_CYCLE_ONE}",
+		"EXPAND_CYCLE_ONE": "${EXPAND_CYCLE_TWO}",
+		"EXPAND_CYCLE_TWO": "${EXPAND_URL}",
+	}, "", env.ErrReferenceCycle))
+}
+
+func TestExpandDefault(t *testing.T) {
+	env.ClearRegister()
+	env.Field("EXPAND_HOST", "localhost")
+	dsn := env.Field("EXPAND_DSN", "postgres://${EXPAND_HOST}/app", env.Expand())
+	literal := env.Field("EXPAND_LITERAL", "postgres://${EXPAND_HOST}/app")
+
+	assert.Equal(t, "postgres://localhost/app", dsn.GetOrDefault())
+	assert.Equal(t, "postgres://${EXPAND_HOST}/app", literal.GetOrDefault())
+
+	t.Setenv("EXPAND_HOST", "db")
+	assert.Equal(t, "postgres://db/app", dsn.GetOrDefault())
+}
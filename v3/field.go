@@ -4,21 +4,16 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"os"
 	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/simia-tech/env/v3/internal/parser"
 )
 
-var (
-	ErrMissingValue = errors.New("missing value")
-	ErrInvalidValue = errors.New("invalid value")
-)
-
 type FieldType interface {
 	bool | []byte | time.Duration | int | []int | string | []string | map[string]string
 }
@@ -28,6 +23,9 @@ type F[T FieldType] struct {
 	location     string
 	defaultValue T
 	options      options
+
+	mutex       sync.Mutex
+	subscribers []func(T, T)
 }
 
 var nameRegexp = regexp.MustCompile("^[A-Z0-9_]+$")
@@ -36,13 +34,23 @@ func Field[T FieldType](name string, defaultValue T, opts ...Option) *F[T] {
 	if !nameRegexp.MatchString(name) {
 		panic(fmt.Sprintf("field name [%s] must only contain capital letters, numbers or underscores", name))
 	}
-	_, filename, line, _ := runtime.Caller(1)
+	o := newOptions(opts)
+	for _, alias := range o.aliases {
+		if !nameRegexp.MatchString(alias) {
+			panic(fmt.Sprintf("alias [%s] of field [%s] must only contain capital letters, numbers or underscores", alias, name))
+		}
+	}
+	location := o.location
+	if location == "" {
+		_, filename, line, _ := runtime.Caller(1)
+		location = fmt.Sprintf("%s:%d", filename, line)
+	}
 
 	f := &F[T]{
 		name:         name,
-		location:     fmt.Sprintf("%s:%d", filename, line),
+		location:     location,
 		defaultValue: defaultValue,
-		options:      newOptions(opts),
+		options:      o,
 	}
 	registerField(f)
 	return f
@@ -53,40 +61,129 @@ func (f *F[T]) Name() string {
 }
 
 func (f *F[T]) Description() string {
-	if f.options.description != "" {
-		return f.options.description
-	}
-	sentences := []string{label[T]() + " field."}
-	if f.options.required {
-		sentences = append(sentences, "Required field.")
-	}
-	if f.options.allowedValues != nil {
-		sentences = append(sentences, fmt.Sprintf("Allowed values are %s.", joinStringValues(f.options.allowedValues)))
+	return describe(f.info())
+}
+
+func (f *F[T]) info() FieldInfo {
+	return FieldInfo{
+		Name:          f.name,
+		Type:          label[T](),
+		DefaultValue:  formatValue[T](f.defaultValue),
+		Description:   f.options.description,
+		Required:      f.options.required,
+		AllowedValues: copyStrings(f.options.allowedValues),
+		Constraints:   constraintSentences(f.name),
+		Sensitive:     f.options.sensitive,
+		Group:         f.options.group,
+		Tags:          copyStrings(f.options.tags),
+		Aliases:       copyStrings(f.options.aliases),
+		Deprecation:   f.options.deprecation,
+		Flag:          f.options.flagName(f.name),
+		Location:      f.location,
 	}
-	sentences = append(sentences, "The default value is '"+formatValue[T](f.defaultValue)+"'.")
-	sentences = append(sentences, "Defined at "+f.location+".")
-	return strings.Join(sentences, " ")
+}
+
+func (f *F[T]) defaultRaw() string {
+	return formatValue[T](f.defaultValue)
+}
+
+func (f *F[T]) typeLabel() string {
+	return label[T]()
+}
+
+func (f *F[T]) fieldLocation() string {
+	return f.location
+}
+
+func (f *F[T]) fieldOptions() *options {
+	return &f.options
 }
 
 func (f *F[T]) GetRaw() (string, error) {
-	text, ok := os.LookupEnv(f.name)
+	return f.getRaw(currentLookup())
+}
+
+func (f *F[T]) getRaw(lookup lookupFunc) (string, error) {
+	text, _, err := f.resolve(lookup)
+	return text, err
+}
+
+// resolve returns the raw value of the field and its source. If the value is missing or not allowed, the default
+// value is returned together with the error.
+func (f *F[T]) resolve(lookup lookupFunc) (string, string, error) {
+	text, source, ok, err := f.lookup(lookup)
+	if err != nil {
+		return formatValue[T](f.defaultValue), source, err
+	}
 	if !ok {
 		if f.options.required {
-			return formatValue[T](f.defaultValue), fmt.Errorf("field [%s]: %w", f.name, ErrMissingValue)
+			return formatValue[T](f.defaultValue), source, f.newError(source, "", ErrMissingValue)
+		}
+		if f.options.expand {
+			// A default value with references is expanded as well.
+			expanded, err := expand(formatValue[T](f.defaultValue), lookup, []string{f.name})
+			if err != nil {
+				return formatValue[T](f.defaultValue), source, f.newError(source, "", err)
+			}
+			return expanded, source, nil
 		}
-		return formatValue[T](f.defaultValue), nil
+		return formatValue[T](f.defaultValue), source, nil
 	}
 	text = strings.TrimSpace(text)
 
+	if f.options.expand {
+		expanded, err := expand(text, lookup, []string{f.name})
+		if err != nil {
+			return formatValue[T](f.defaultValue), source, f.newError(source, text, err)
+		}
+		text = expanded
+	}
+
 	if !f.options.isAllowedValue(text) {
-		return formatValue[T](f.defaultValue), fmt.Errorf("field [%s]: value [%s]: %w", f.name, text, ErrInvalidValue)
+		return formatValue[T](f.defaultValue), source, f.newError(source, text, ErrInvalidValue)
 	}
 
-	return text, nil
+	return text, source, nil
+}
+
+// lookup returns the value of the field or, if the field is not set, the value of the first alias that is set.
+func (f *F[T]) lookup(lookup lookupFunc) (string, string, bool, error) {
+	value, source, ok := lookup(f.name)
+	if ok && f.options.deprecation != "" {
+		warnOnce(f.name, fmt.Errorf("field [%s]: %w: %s", f.name, ErrDeprecated, f.options.deprecation))
+	}
+	for _, alias := range f.options.aliases {
+		aliasValue, aliasSource, aliasOK := lookup(alias)
+		if !aliasOK {
+			continue
+		}
+		warnOnce(alias, fmt.Errorf("field [%s]: name [%s]: %w", f.name, alias, ErrDeprecated))
+		if !ok {
+			value, source, ok = aliasValue, aliasSource, true
+			continue
+		}
+		if aliasValue != value {
+			return "", source, true, f.newError(source, value, fmt.Errorf("name [%s] with value [%s]: %w", alias, aliasValue, ErrConflictingValues))
+		}
+	}
+	return value, source, ok, nil
+}
+
+func (f *F[T]) newError(source, raw string, cause error) *FieldError {
+	return &FieldError{
+		Name:     f.name,
+		Raw:      raw,
+		Location: f.location,
+		Source:   source,
+		Cause:    cause,
+	}
 }
 
 func (f *F[T]) GetRawOrDefault() string {
-	value, _ := f.GetRaw()
+	value, err := f.GetRaw()
+	if err != nil {
+		handleError(&f.options, err)
+	}
 	return value
 }
 
@@ -99,21 +196,44 @@ func (f *F[T]) MustGetRaw() string {
 }
 
 func (f *F[T]) Get() (T, error) {
-	raw, err := f.GetRaw()
+	if s := loadedSnapshot(); s != nil {
+		if value, ok := s.values[f]; ok {
+			return value.(T), nil
+		}
+	}
+	return f.get(currentLookup())
+}
+
+func (f *F[T]) get(lookup lookupFunc) (T, error) {
+	raw, source, err := f.resolve(lookup)
 	if err != nil {
 		return f.defaultValue, err
 	}
 
 	result, err := parseValue[T](raw)
 	if err != nil {
-		return f.defaultValue, fmt.Errorf("field [%s]: %w", f.name, err)
+		return f.defaultValue, f.newError(source, raw, err)
 	}
 
 	return result, nil
 }
 
+// value returns the parsed value of the field, or the default value and the error.
+func (f *F[T]) value(lookup lookupFunc) (any, error) {
+	return f.get(lookup)
+}
+
+// text returns the formatted value of the field, or the formatted default value and the error.
+func (f *F[T]) text(lookup lookupFunc) (string, error) {
+	value, err := f.get(lookup)
+	return formatValue[T](value), err
+}
+
 func (f *F[T]) GetOrDefault() T {
-	value, _ := f.Get()
+	value, err := f.Get()
+	if err != nil {
+		handleError(&f.options, err)
+	}
 	return value
 }
 
@@ -125,6 +245,50 @@ func (f *F[T]) MustGet() T {
 	return value
 }
 
+// OnChange registers a function that is called with the old and the new value, each time a Reload changes the
+// value of the field.
+func (f *F[T]) OnChange(fn func(old, new T)) {
+	f.mutex.Lock()
+	f.subscribers = append(f.subscribers, fn)
+	f.mutex.Unlock()
+}
+
+// reload compares the values of the field in the current and the staged lookup. If the value has changed, the new
+// value is validated and a function is returned, that notifies the subscribers.
+func (f *F[T]) reload(current, staged lookupFunc) (func(), error) {
+	if !f.changed(current, staged) {
+		return nil, nil
+	}
+
+	newValue, err := f.get(staged)
+	if err != nil {
+		return nil, err
+	}
+	oldValue, _ := f.get(current)
+
+	f.mutex.Lock()
+	subscribers := append([]func(T, T){}, f.subscribers...)
+	f.mutex.Unlock()
+
+	return func() {
+		for _, fn := range subscribers {
+			fn(oldValue, newValue)
+		}
+	}, nil
+}
+
+// changed returns true if the value of the field or of one of its aliases differs between the lookups.
+func (f *F[T]) changed(current, staged lookupFunc) bool {
+	for _, name := range append([]string{f.name}, f.options.aliases...) {
+		oldRaw, _, oldOK := current(name)
+		newRaw, _, newOK := staged(name)
+		if oldRaw != newRaw || oldOK != newOK {
+			return true
+		}
+	}
+	return false
+}
+
 func label[T FieldType]() string {
 	switch any(*new(T)).(type) {
 	case bool:
@@ -160,34 +324,34 @@ func parseValue[T FieldType](raw string) (T, error) {
 		case "0", "false", "no":
 			result = false
 		default:
-			return value, fmt.Errorf("parse bool [%s]: %w", raw, ErrInvalidValue)
+			return value, fmt.Errorf("parse bool: %w", ErrInvalidValue)
 		}
 
 	case []byte:
 		v, err := hex.DecodeString(raw)
 		if err != nil {
-			return value, fmt.Errorf("parse hex [%s]: %w", raw, ErrInvalidValue)
+			return value, fmt.Errorf("parse hex: %w", ErrInvalidValue)
 		}
 		result = v
 
 	case time.Duration:
 		v, err := time.ParseDuration(raw)
 		if err != nil {
-			return value, fmt.Errorf("parse duration [%s]: %w", raw, ErrInvalidValue)
+			return value, fmt.Errorf("parse duration: %w", ErrInvalidValue)
 		}
 		result = v
 
 	case int:
 		v, err := strconv.ParseInt(raw, 10, 64)
 		if err != nil {
-			return value, fmt.Errorf("parse int [%s]: %w", raw, ErrInvalidValue)
+			return value, fmt.Errorf("parse int: %w", ErrInvalidValue)
 		}
 		result = int(v)
 
 	case []int:
 		v, err := parser.ParseInts(raw)
 		if err != nil {
-			return value, fmt.Errorf("parse int array [%s]: %w", raw, err)
+			return value, newParseError(raw, err)
 		}
 		result = v
 
@@ -197,14 +361,14 @@ func parseValue[T FieldType](raw string) (T, error) {
 	case []string:
 		v, err := parser.ParseStrings(raw)
 		if err != nil {
-			return value, fmt.Errorf("parse string array [%s]: %w", raw, err)
+			return value, newParseError(raw, err)
 		}
 		result = v
 
 	case map[string]string:
 		m, err := parser.ParseStringMap(raw)
 		if err != nil {
-			return value, fmt.Errorf("parse string map [%s]: %w", raw, err)
+			return value, newParseError(raw, err)
 		}
 		result = m
 
@@ -213,6 +377,14 @@ func parseValue[T FieldType](raw string) (T, error) {
 	return result.(T), nil
 }
 
+func newParseError(raw string, err error) error {
+	var pe *parser.Error
+	if errors.As(err, &pe) {
+		return &ParseError{Raw: raw, Index: pe.Index, Cause: pe.Err}
+	}
+	return err
+}
+
 func formatValue[T FieldType](value T) string {
 	switch t := any(value).(type) {
 	case bool:
@@ -27,6 +27,7 @@ import (
 
 func TestField(t *testing.T) {
 	t.Run("Bool", func(t *testing.T) {
+		env.ClearRegister()
 		field := env.Field("OPTIONAL_FIELD", false)
 
 		t.Run("Value", testSetFn(field, "1", true, nil))
@@ -36,6 +37,7 @@ func TestField(t *testing.T) {
 	})
 
 	t.Run("Bytes", func(t *testing.T) {
+		env.ClearRegister()
 		field := env.Field("OPTIONAL_FIELD", []byte{0, 1, 2, 3})
 
 		t.Run("Value", testSetFn(field, "ffeeddcc", []byte{0xff, 0xee, 0xdd, 0xcc}, nil))
@@ -45,6 +47,7 @@ func TestField(t *testing.T) {
 	})
 
 	t.Run("Duration", func(t *testing.T) {
+		env.ClearRegister()
 		field := env.Field("OPTIONAL_FIELD", 5*time.Second)
 
 		t.Run("Value", testSetFn(field, "10s", 10*time.Second, nil))
@@ -54,6 +57,7 @@ func TestField(t *testing.T) {
 	})
 
 	t.Run("Int", func(t *testing.T) {
+		env.ClearRegister()
 		field := env.Field("OPTIONAL_FIELD", 1)
 
 		t.Run("Value", testSetFn(field, "2", 2, nil))
@@ -63,6 +67,7 @@ func TestField(t *testing.T) {
 	})
 
 	t.Run("IntArray", func(t *testing.T) {
+		env.ClearRegister()
 		field := env.Field("OPTIONAL_FIELD", []int{1})
 
 		t.Run("Value", testSetFn(field, "2", []int{2}, nil))
@@ -71,6 +76,7 @@ func TestField(t *testing.T) {
 	})
 
 	t.Run("String", func(t *testing.T) {
+		env.ClearRegister()
 		optional := env.Field("OPTIONAL_FIELD", "abc")
 		required := env.Field("REQUIRED_FIELD", "abc", env.Required())
 		allowed := env.Field("ALLOWED_FIELD", "abc", env.AllowedValues("abc", "def"))
@@ -85,6 +91,7 @@ func TestField(t *testing.T) {
 	})
 
 	t.Run("StringArray", func(t *testing.T) {
+		env.ClearRegister()
 		field := env.Field("OPTIONAL_FIELD", []string{"abc"})
 
 		t.Run("Value", testSetFn(field, "def", []string{"def"}, nil))
@@ -93,6 +100,7 @@ func TestField(t *testing.T) {
 	})
 
 	t.Run("StringStringMap", func(t *testing.T) {
+		env.ClearRegister()
 		field := env.Field("OPTIONAL_FIELD", map[string]string{"abc": "123"})
 
 		t.Run("Value", testSetFn(field, "def:123", map[string]string{"def": "123"}, nil))
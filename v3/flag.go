@@ -0,0 +1,90 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"flag"
+	"sync"
+)
+
+var flagValues sync.Map
+
+// BindFlags defines a flag in the provided flag set for each registered field with the option Flag or AutoFlag.
+// The fields can be further selected by the provided options. The usage text of each flag is the field's
+// description. A value given via a flag takes precedence over the values of the environment and the sources.
+func BindFlags(fs *flag.FlagSet, opts ...PrintOption) {
+	for _, info := range newPrintOptions(opts).selectFields(Fields()) {
+		if info.Flag == "" {
+			continue
+		}
+		value := &flagValue{field: findField(info.Name)}
+		if info.Type == label[bool]() {
+			fs.Var(&boolFlagValue{value}, info.Flag, describe(info))
+		} else {
+			fs.Var(value, info.Flag, describe(info))
+		}
+	}
+}
+
+func lookupFlag(name string) (string, bool) {
+	value, ok := flagValues.Load(name)
+	if !ok {
+		return "", false
+	}
+	return value.(string), true
+}
+
+func clearFlags() {
+	flagValues.Range(func(name, _ any) bool {
+		flagValues.Delete(name)
+		return true
+	})
+}
+
+type flagValue struct {
+	field generalField
+}
+
+func (fv *flagValue) String() string {
+	if fv == nil || fv.field == nil {
+		return ""
+	}
+	return fv.field.defaultRaw()
+}
+
+// Set validates the value before it's stored, so an invalid value is reported by the flag set.
+func (fv *flagValue) Set(value string) error {
+	name := fv.field.Name()
+	lookup := currentLookup()
+	_, err := fv.field.value(func(n string) (string, string, bool) {
+		if n == name {
+			return value, "flag", true
+		}
+		return lookup(n)
+	})
+	if err != nil {
+		return err
+	}
+	flagValues.Store(name, value)
+	return nil
+}
+
+type boolFlagValue struct {
+	*flagValue
+}
+
+func (bfv *boolFlagValue) IsBoolFlag() bool {
+	return true
+}
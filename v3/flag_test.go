@@ -0,0 +1,95 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v3"
+)
+
+func TestBindFlags(t *testing.T) {
+	newFlagSet := func() *flag.FlagSet {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		env.BindFlags(fs)
+		return fs
+	}
+	flagName := func(name string) string {
+		info, _ := env.Lookup(name)
+		return info.Flag
+	}
+
+	t.Run("Names", func(t *testing.T) {
+		env.ClearRegister()
+		env.Field("FLAG_DB_URL", "", env.Flag("db-url"))
+		env.Field("FLAG_PORT", 8080, env.AutoFlag(), env.Description("Port to listen on."))
+		env.Field("FLAG_OTHER", "")
+
+		fs := newFlagSet()
+		assert.Equal(t, "db-url", flagName("FLAG_DB_URL"))
+		assert.Equal(t, "flag-port", flagName("FLAG_PORT"))
+		assert.Equal(t, "", flagName("FLAG_OTHER"))
+		require.NotNil(t, fs.Lookup("flag-port"))
+		assert.Equal(t, "Port to listen on.", fs.Lookup("flag-port").Usage)
+		assert.Equal(t, "8080", fs.Lookup("flag-port").DefValue)
+		assert.Nil(t, fs.Lookup("flag-other"))
+	})
+
+	t.Run("Precedence", func(t *testing.T) {
+		env.ClearRegister()
+		env.AddSource(mapSource{"FLAG_THREE": "source", "FLAG_FOUR": "source"})
+		one := env.Field("FLAG_ONE", "default", env.AutoFlag())
+		two := env.Field("FLAG_TWO", "default", env.AutoFlag())
+		three := env.Field("FLAG_THREE", "default", env.AutoFlag())
+		four := env.Field("FLAG_FOUR", "default", env.AutoFlag())
+		five := env.Field("FLAG_FIVE", "default", env.AutoFlag())
+
+		t.Setenv("FLAG_ONE", "env")
+		t.Setenv("FLAG_TWO", "env")
+		require.NoError(t, newFlagSet().Parse([]string{"-flag-one", "flag", "-flag-three", "flag"}))
+
+		assert.Equal(t, "flag", one.GetOrDefault())
+		assert.Equal(t, "env", two.GetOrDefault())
+		assert.Equal(t, "flag", three.GetOrDefault())
+		assert.Equal(t, "source", four.GetOrDefault())
+		assert.Equal(t, "default", five.GetOrDefault())
+
+		info, _ := env.Lookup("FLAG_ONE")
+		assert.Equal(t, "flag", info.Source)
+	})
+
+	t.Run("Bool", func(t *testing.T) {
+		env.ClearRegister()
+		debug := env.Field("FLAG_DEBUG", false, env.AutoFlag())
+
+		require.NoError(t, newFlagSet().Parse([]string{"-flag-debug"}))
+		assert.True(t, debug.GetOrDefault())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		env.ClearRegister()
+		env.Field("FLAG_PORT", 8080, env.AutoFlag())
+
+		assert.Error(t, newFlagSet().Parse([]string{"-flag-port", "abc"}))
+	})
+
+	env.ClearRegister()
+}
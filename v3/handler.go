@@ -0,0 +1,185 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/simia-tech/env/v3/internal/parser"
+)
+
+const redactedValue = "[redacted]"
+
+// redactableCauses lists the causes whose messages never contain the raw value.
+var redactableCauses = []error{
+	ErrMissingValue,
+	ErrUndefinedReference,
+	ErrUnterminatedReference,
+	ErrReferenceCycle,
+	ErrConflictingValues,
+	parser.ErrEmptyKey,
+	parser.ErrUnexpectedRune,
+	parser.ErrUnterminatedQuote,
+	parser.ErrDanglingEscape,
+	parser.ErrInvalidEscape,
+}
+
+// HandlerOption defines an option that modifies the handler returned by Handler.
+type HandlerOption func(*handlerOptions)
+
+type handlerOptions struct {
+	token string
+}
+
+// HandlerToken returns a HandlerOption that requires each request to provide the given token, either via the
+// header `Authorization: Bearer <token>` or via the query parameter `token`.
+func HandlerToken(token string) HandlerOption {
+	return func(o *handlerOptions) {
+		o.token = token
+	}
+}
+
+// Handler returns an http.Handler that serves all registered fields with their effective value, default value,
+// source and validation status. The values of sensitive fields are redacted. By default, the response is JSON.
+// HTML is served if the query parameter `format=html` is given or the request accepts `text/html`.
+func Handler(opts ...HandlerOption) http.Handler {
+	o := &handlerOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if o.token != "" && !hasToken(r, o.token) {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		states := fieldStates()
+		if r.URL.Query().Get("format") == "html" || strings.Contains(r.Header.Get("Accept"), "text/html") {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			if err := handlerTemplate.Execute(w, states); err != nil {
+				log.Printf("env handler: %v", err)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(states); err != nil {
+			log.Printf("env handler: %v", err)
+		}
+	})
+}
+
+type fieldState struct {
+	Name         string `json:"name"`
+	Value        string `json:"value"`
+	DefaultValue string `json:"default"`
+	Source       string `json:"source"`
+	Sensitive    bool   `json:"sensitive,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+func fieldStates() []fieldState {
+	states := []fieldState{}
+	for _, info := range Fields() {
+		state := fieldState{
+			Name:         info.Name,
+			Value:        info.Value,
+			DefaultValue: info.DefaultValue,
+			Source:       info.Source,
+		}
+		if info.Err != nil {
+			state.Error = info.Err.Error()
+		}
+		if info.Sensitive {
+			state.Sensitive = true
+			state.Value = redact(state.Value)
+			state.DefaultValue = redact(state.DefaultValue)
+			if info.Err != nil {
+				state.Error = redactError(info.Err).Error()
+			}
+		}
+		states = append(states, state)
+	}
+	return states
+}
+
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redactedValue
+}
+
+// redactError returns an error that describes err without the raw value. Only the field's name and the sentinel
+// cause are kept, since the messages of the wrapping errors may quote the raw value.
+func redactError(err error) error {
+	fieldErr := &FieldError{}
+	if !errors.As(err, &fieldErr) {
+		return redactCause(err)
+	}
+	return &FieldError{
+		Name:     fieldErr.Name,
+		Location: fieldErr.Location,
+		Source:   fieldErr.Source,
+		Cause:    redactCause(fieldErr.Cause),
+	}
+}
+
+func redactCause(err error) error {
+	parseErr := &ParseError{}
+	if errors.As(err, &parseErr) {
+		return &ParseError{Index: parseErr.Index, Cause: redactCause(parseErr.Cause)}
+	}
+	for _, cause := range redactableCauses {
+		if errors.Is(err, cause) {
+			return cause
+		}
+	}
+	return ErrInvalidValue
+}
+
+func hasToken(r *http.Request, token string) bool {
+	given := r.URL.Query().Get("token")
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, "Bearer ") {
+		given = strings.TrimPrefix(header, "Bearer ")
+	}
+	return subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
+
+var handlerTemplate = template.Must(template.New("handler").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Environment</title></head>
+<body>
+<table>
+  <thead>
+    <tr><th>Name</th><th>Value</th><th>Default</th><th>Source</th><th>Status</th></tr>
+  </thead>
+  <tbody>
+{{- range . }}
+    <tr><td><code>{{ .Name }}</code></td><td><code>{{ .Value }}</code></td><td><code>{{ .DefaultValue }}</code></td><td>{{ .Source }}</td><td>{{ if .Error }}{{ .Error }}{{ else }}ok{{ end }}</td></tr>
+{{- end }}
+  </tbody>
+</table>
+</body>
+</html>
+`))
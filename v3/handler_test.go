@@ -0,0 +1,96 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v3"
+)
+
+func TestHandler(t *testing.T) {
+	env.ClearRegister()
+	env.Field("HANDLER_NAME", "joe")
+	env.Field("HANDLER_PORT", 8080)
+	env.Field("HANDLER_PIN", 0, env.Sensitive())
+	env.Field("HANDLER_TTL", time.Duration(0), env.Sensitive())
+	env.Field("HANDLER_PASSWORD", "", env.Sensitive(), env.AllowedValues("", "secret"))
+	env.Field("HANDLER_TOKENS", []string(nil), env.Sensitive())
+	t.Setenv("HANDLER_NAME", "jane")
+	t.Setenv("HANDLER_PORT", "abc")
+	t.Setenv("HANDLER_PIN", "hunter2")
+	t.Setenv("HANDLER_TTL", "s3cr3t")
+	t.Setenv("HANDLER_PASSWORD", "wrong")
+	t.Setenv("HANDLER_TOKENS", `"t0ken`)
+
+	request := func(handler http.Handler, target string, header http.Header) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		for key, values := range header {
+			r.Header[key] = values
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		w := request(env.Handler(), "/", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+		states := []map[string]any{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &states))
+		require.Len(t, states, 6)
+		assert.Equal(t, map[string]any{"name": "HANDLER_NAME", "value": "jane", "default": "joe", "source": "environment"}, states[0])
+		assert.Equal(t, "8080", states[1]["value"])
+		assert.Contains(t, states[1]["error"], "abc")
+		assert.Equal(t, map[string]any{
+			"name":      "HANDLER_PIN",
+			"value":     "[redacted]",
+			"default":   "[redacted]",
+			"source":    "environment",
+			"sensitive": true,
+			"error":     "field [HANDLER_PIN]: invalid value",
+		}, states[2])
+		assert.Equal(t, "field [HANDLER_TTL]: invalid value", states[3]["error"])
+		assert.Equal(t, "field [HANDLER_PASSWORD]: invalid value", states[4]["error"])
+		assert.Equal(t, "field [HANDLER_TOKENS]: at index 0: unterminated quote", states[5]["error"])
+		for _, secret := range []string{"hunter2", "s3cr3t", "wrong", "t0ken"} {
+			assert.NotContains(t, w.Body.String(), secret)
+		}
+	})
+
+	t.Run("HTML", func(t *testing.T) {
+		w := request(env.Handler(), "/?format=html", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "<tr><td><code>HANDLER_NAME</code></td><td><code>jane</code></td><td><code>joe</code></td><td>environment</td><td>ok</td></tr>")
+		assert.NotContains(t, w.Body.String(), "hunter2")
+	})
+
+	t.Run("Token", func(t *testing.T) {
+		handler := env.Handler(env.HandlerToken("abc"))
+		assert.Equal(t, http.StatusUnauthorized, request(handler, "/", nil).Code)
+		assert.Equal(t, http.StatusUnauthorized, request(handler, "/?token=def", nil).Code)
+		assert.Equal(t, http.StatusOK, request(handler, "/?token=abc", nil).Code)
+		assert.Equal(t, http.StatusOK, request(handler, "/", http.Header{"Authorization": {"Bearer abc"}}).Code)
+	})
+}
@@ -0,0 +1,110 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldInfo holds the metadata of a registered field together with its effective value. Values of sensitive fields
+// are not redacted.
+type FieldInfo struct {
+	Name          string
+	Type          string
+	DefaultValue  string
+	Description   string
+	Required      bool
+	AllowedValues []string
+	Constraints   []string
+	Sensitive     bool
+	Group         string
+	Tags          []string
+	Aliases       []string
+	Deprecation   string
+	Flag          string
+	Location      string
+
+	// Value holds the effective value of the field. If the field holds an invalid value, Value is the default value
+	// and Err holds the error.
+	Value  string
+	Source string
+	Err    error
+}
+
+// Fields returns the information about all registered fields in the order of their registration.
+func Fields() []FieldInfo {
+	lookup := currentLookup()
+	infos := []FieldInfo{}
+	for _, field := range fields {
+		infos = append(infos, newFieldInfo(field, lookup))
+	}
+	return infos
+}
+
+// Lookup returns the information about the registered field with the provided name. If no such field exists,
+// false is returned.
+func Lookup(name string) (FieldInfo, bool) {
+	field := findField(name)
+	if field == nil {
+		return FieldInfo{}, false
+	}
+	return newFieldInfo(field, currentLookup()), true
+}
+
+func newFieldInfo(field generalField, lookup lookupFunc) FieldInfo {
+	info := field.info()
+	info.Value, info.Err = field.text(lookup)
+	info.Source = sourceIn(lookup, field.Name())
+	for _, alias := range info.Aliases {
+		if info.Source != "default" {
+			break
+		}
+		info.Source = sourceIn(lookup, alias)
+	}
+	return info
+}
+
+// describe returns the field's description. If no description is set, it's generated from the field's type and
+// options.
+func describe(info FieldInfo) string {
+	if info.Description != "" {
+		return info.Description
+	}
+	sentences := []string{info.Type + " field."}
+	if info.Deprecation != "" {
+		sentences = append(sentences, "Deprecated: "+info.Deprecation+".")
+	}
+	if info.Required {
+		sentences = append(sentences, "Required field.")
+	}
+	sentences = append(sentences, info.Constraints...)
+	if info.Aliases != nil {
+		sentences = append(sentences, fmt.Sprintf("Deprecated names are %s.", joinStringValues(info.Aliases)))
+	}
+	if info.AllowedValues != nil {
+		sentences = append(sentences, fmt.Sprintf("Allowed values are %s.", joinStringValues(info.AllowedValues)))
+	}
+	sentences = append(sentences, "The default value is '"+info.DefaultValue+"'.")
+	sentences = append(sentences, "Defined at "+info.Location+".")
+	return strings.Join(sentences, " ")
+}
+
+func copyStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	return append([]string{}, values...)
+}
@@ -0,0 +1,60 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v3"
+)
+
+func TestFields(t *testing.T) {
+	env.ClearRegister()
+	env.Field("INFO_CERT", "", env.Description("TLS certificate."))
+	env.Field("INFO_KEY", []byte(nil), env.Sensitive())
+	env.Field("INFO_PORT", 8080, env.Required(), env.AllowedValues("80", "8080"))
+	t.Setenv("INFO_CERT", "cert.pem")
+	t.Setenv("INFO_PORT", "443")
+
+	infos := env.Fields()
+	require.Len(t, infos, 3)
+	assert.Regexp(t, `info_test\.go:\d+$`, infos[0].Location)
+	infos[0].Location = ""
+	assert.Equal(t, env.FieldInfo{
+		Name:        "INFO_CERT",
+		Type:        "String",
+		Description: "TLS certificate.",
+		Value:       "cert.pem",
+		Source:      "environment",
+	}, infos[0])
+	assert.Equal(t, "INFO_KEY", infos[1].Name)
+	assert.True(t, infos[1].Sensitive)
+	assert.Equal(t, "default", infos[1].Source)
+
+	info, ok := env.Lookup("INFO_PORT")
+	require.True(t, ok)
+	assert.Equal(t, "Int", info.Type)
+	assert.Equal(t, "8080", info.DefaultValue)
+	assert.True(t, info.Required)
+	assert.Equal(t, []string{"80", "8080"}, info.AllowedValues)
+	assert.Equal(t, "8080", info.Value)
+	assert.ErrorIs(t, info.Err, env.ErrInvalidValue)
+
+	_, ok = env.Lookup("INFO_MISSING")
+	assert.False(t, ok)
+}
@@ -0,0 +1,6 @@
+// Package location allows the tools of this module to set the location of a field, that is otherwise taken from
+// the caller of env.Field. It's not part of the public API.
+package location
+
+// Option returns an env.Option, that sets the location of a field. It's assigned by the env package.
+var Option func(location string) interface{}
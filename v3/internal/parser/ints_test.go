@@ -26,6 +26,13 @@ func TestParseInts(t *testing.T) {
 		t.Run(testFn(`"1", 2`, []int{1, 2}))
 	})
 
+	t.Run("LastValueError", func(t *testing.T) {
+		_, err := parser.ParseInts(`1,x`)
+		pe := (*parser.Error)(nil)
+		require.ErrorAs(t, err, &pe)
+		assert.Equal(t, 3, pe.Index)
+	})
+
 	t.Run("Format", func(t *testing.T) {
 		testFn := func(raw []int, expected string) (string, func(*testing.T)) {
 			return expected, func(t *testing.T) {
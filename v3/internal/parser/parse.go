@@ -3,48 +3,69 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 var (
-	ErrEmptyKey       = errors.New("empty key")
-	ErrUnexpectedRune = errors.New("unexpected rune")
+	ErrEmptyKey          = errors.New("empty key")
+	ErrUnexpectedRune    = errors.New("unexpected rune")
+	ErrUnterminatedQuote = errors.New("unterminated quote")
+	ErrDanglingEscape    = errors.New("dangling escape")
+	ErrInvalidEscape     = errors.New("invalid escape sequence")
 )
 
 type EmitFunc func(string, string) error
 
-func ParseKeyValues(raw string, emitFn EmitFunc) error {
-	s := state{parseValues: true, emitFn: emitFn}
-
-	parseFn := parseFunc(parseKeyBegin)
-	err := error(nil)
+type Error struct {
+	Index int
+	Err   error
+}
 
-	for index, c := range raw {
-		parseFn, err = parseFn(&s, c)
-		if err != nil {
-			return fmt.Errorf("at index %d: %w", index, err)
-		}
-	}
+func (e *Error) Error() string {
+	return fmt.Sprintf("at index %d: %v", e.Index, e.Err)
+}
 
-	s.emit()
+func (e *Error) Unwrap() error {
+	return e.Err
+}
 
-	return nil
+func ParseKeyValues(raw string, emitFn EmitFunc) error {
+	return parse(raw, &state{parseValues: true, emitFn: emitFn, quoteIndex: -1, escapeIndex: -1})
 }
 
 func ParseKeys(raw string, emitFn EmitFunc) error {
-	s := state{parseValues: false, emitFn: emitFn}
+	return parse(raw, &state{parseValues: false, emitFn: emitFn, quoteIndex: -1, escapeIndex: -1})
+}
 
+func parse(raw string, s *state) error {
 	parseFn := parseFunc(parseKeyBegin)
 	err := error(nil)
 
 	for index, c := range raw {
-		parseFn, err = parseFn(&s, c)
+		s.index = index
+		parseFn, err = parseFn(s, c)
 		if err != nil {
-			return fmt.Errorf("at index %d: %w", index, err)
+			if e := (*Error)(nil); errors.As(err, &e) {
+				return err
+			}
+			return &Error{Index: index, Err: err}
 		}
 	}
 
-	s.emit()
+	if s.escapeIndex >= 0 {
+		return &Error{Index: s.escapeIndex, Err: ErrDanglingEscape}
+	}
+	if s.quoteIndex >= 0 {
+		return &Error{Index: s.quoteIndex, Err: ErrUnterminatedQuote}
+	}
+	if s.key.Len() == 0 && !s.keyQuoted && s.value.Len() == 0 {
+		return nil
+	}
+	if err := s.emit(); err != nil {
+		return &Error{Index: len(raw), Err: err}
+	}
 
 	return nil
 }
@@ -60,8 +81,10 @@ func parseKeyBegin(s *state, c rune) (parseFunc, error) {
 		}
 		return parseKeyBegin, nil
 	case '\'':
+		s.openQuote(true)
 		return parseSingleQuotedKey, nil
 	case '"':
+		s.openQuote(true)
 		return parseDoubleQuotedKey, nil
 	default:
 		s.key.WriteRune(c)
@@ -111,11 +134,9 @@ func parseKeyRaw(s *state, c rune) (parseFunc, error) {
 func parseSingleQuotedKey(s *state, c rune) (parseFunc, error) {
 	switch c {
 	case '\\':
-		return func(s *state, c rune) (parseFunc, error) {
-			s.key.WriteRune(c)
-			return parseSingleQuotedKey, nil
-		}, nil
+		return s.parseEscape(&s.key, parseSingleQuotedKey), nil
 	case '\'':
+		s.closeQuote()
 		return parseKeyEnd, nil
 	default:
 		s.key.WriteRune(c)
@@ -126,11 +147,9 @@ func parseSingleQuotedKey(s *state, c rune) (parseFunc, error) {
 func parseDoubleQuotedKey(s *state, c rune) (parseFunc, error) {
 	switch c {
 	case '\\':
-		return func(s *state, c rune) (parseFunc, error) {
-			s.key.WriteRune(c)
-			return parseDoubleQuotedKey, nil
-		}, nil
+		return s.parseEscape(&s.key, parseDoubleQuotedKey), nil
 	case '"':
+		s.closeQuote()
 		return parseKeyEnd, nil
 	default:
 		s.key.WriteRune(c)
@@ -147,8 +166,10 @@ func parseValueBegin(s *state, c rune) (parseFunc, error) {
 		}
 		return parseKeyBegin, nil
 	case '\'':
+		s.openQuote(false)
 		return parseSingleQuotedValue, nil
 	case '"':
+		s.openQuote(false)
 		return parseDoubleQuotedValue, nil
 	default:
 		s.value.WriteRune(c)
@@ -187,11 +208,9 @@ func parseValueRaw(s *state, c rune) (parseFunc, error) {
 func parseSingleQuotedValue(s *state, c rune) (parseFunc, error) {
 	switch c {
 	case '\\':
-		return func(s *state, c rune) (parseFunc, error) {
-			s.value.WriteRune(c)
-			return parseSingleQuotedValue, nil
-		}, nil
+		return s.parseEscape(&s.value, parseSingleQuotedValue), nil
 	case '\'':
+		s.closeQuote()
 		return parseValueEnd, nil
 	default:
 		s.value.WriteRune(c)
@@ -202,11 +221,9 @@ func parseSingleQuotedValue(s *state, c rune) (parseFunc, error) {
 func parseDoubleQuotedValue(s *state, c rune) (parseFunc, error) {
 	switch c {
 	case '\\':
-		return func(s *state, c rune) (parseFunc, error) {
-			s.value.WriteRune(c)
-			return parseDoubleQuotedValue, nil
-		}, nil
+		return s.parseEscape(&s.value, parseDoubleQuotedValue), nil
 	case '"':
+		s.closeQuote()
 		return parseValueEnd, nil
 	default:
 		s.value.WriteRune(c)
@@ -217,12 +234,74 @@ func parseDoubleQuotedValue(s *state, c rune) (parseFunc, error) {
 type state struct {
 	parseValues bool
 	key         strings.Builder
+	keyQuoted   bool
 	value       strings.Builder
 	emitFn      EmitFunc
+	index       int
+	quoteIndex  int
+	escapeIndex int
+}
+
+func (s *state) openQuote(key bool) {
+	s.quoteIndex = s.index
+	if key {
+		s.keyQuoted = true
+	}
+}
+
+func (s *state) closeQuote() {
+	s.quoteIndex = -1
+}
+
+// parseEscape returns a parseFunc that reads the escape sequence, which has been started with a backslash, writes
+// the resulting character to the target and continues with the next parseFunc. The sequences of strconv.Quote are
+// supported.
+func (s *state) parseEscape(target *strings.Builder, next parseFunc) parseFunc {
+	s.escapeIndex = s.index
+	sequence := []rune{'\\'}
+	var parseFn parseFunc
+	parseFn = func(s *state, c rune) (parseFunc, error) {
+		sequence = append(sequence, c)
+		if len(sequence) < escapeLength(sequence[1]) {
+			return parseFn, nil
+		}
+
+		if c := sequence[1]; c == '\'' || c == '"' {
+			target.WriteRune(c)
+		} else {
+			value, multibyte, tail, err := strconv.UnquoteChar(string(sequence), 0)
+			if err != nil || tail != "" {
+				return nil, &Error{Index: s.escapeIndex, Err: ErrInvalidEscape}
+			}
+			if value < utf8.RuneSelf || multibyte {
+				target.WriteRune(value)
+			} else {
+				target.WriteByte(byte(value))
+			}
+		}
+		s.escapeIndex = -1
+		return next, nil
+	}
+	return parseFn
+}
+
+func escapeLength(c rune) int {
+	switch {
+	case c == 'x':
+		return 4
+	case c == 'u':
+		return 6
+	case c == 'U':
+		return 10
+	case c >= '0' && c <= '7':
+		return 4
+	default:
+		return 2
+	}
 }
 
 func (s *state) emit() error {
-	if s.key.Len() == 0 {
+	if s.key.Len() == 0 && !s.keyQuoted {
 		return ErrEmptyKey
 	}
 
@@ -231,6 +310,7 @@ func (s *state) emit() error {
 	}
 
 	s.key.Reset()
+	s.keyQuoted = false
 	s.value.Reset()
 
 	return nil
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -18,9 +19,16 @@ func ParseStringMap(raw string) (map[string]string, error) {
 }
 
 func FormatStringMap(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
 	s := strings.Builder{}
-	for key, value := range m {
-		s.WriteString(key)
+	for _, key := range keys {
+		value := m[key]
+		s.WriteString(formatKey(key))
 		if value != "" {
 			s.WriteString(":")
 			s.WriteString(strconv.Quote(value))
@@ -29,3 +37,10 @@ func FormatStringMap(m map[string]string) string {
 	}
 	return strings.TrimSuffix(s.String(), ",")
 }
+
+func formatKey(key string) string {
+	if key == "" || strings.ContainsAny(key, " :,'") || strconv.Quote(key) != `"`+key+`"` {
+		return strconv.Quote(key)
+	}
+	return key
+}
@@ -46,5 +46,23 @@ func TestParseStringMap(t *testing.T) {
 		t.Run(testFn(map[string]string{"one": ""}, "one"))
 		t.Run(testFn(map[string]string{"one": "value"}, `one:"value"`))
 		t.Run(testFn(map[string]string{"one": `value "123"`}, `one:"value \"123\""`))
+		t.Run(testFn(map[string]string{"two": "", "one": "value"}, `one:"value",two`))
+		t.Run(testFn(map[string]string{"one two": "value"}, `"one two":"value"`))
+		t.Run(testFn(map[string]string{"one:two": "line\nbreak"}, `"one:two":"line\nbreak"`))
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		testFn := func(m map[string]string) (string, func(*testing.T)) {
+			raw := parser.FormatStringMap(m)
+			return raw, func(t *testing.T) {
+				parsed, err := parser.ParseStringMap(raw)
+				require.NoError(t, err)
+				assert.Equal(t, m, parsed)
+			}
+		}
+
+		t.Run(testFn(map[string]string{"one": `value "123"`, "two": ""}))
+		t.Run(testFn(map[string]string{"": "empty", "'key'": `back\slash`}))
+		t.Run(testFn(map[string]string{"a,b": "tab\tnew\nline\x00\xff", "é": "\U0001F600"}))
 	})
 }
@@ -26,6 +26,7 @@ func TestParseStrings(t *testing.T) {
 		t.Run(testFn(`one,"two"`, []string{"one", "two"}))
 		t.Run(testFn(`one,"two \"123\""`, []string{"one", `two "123"`}))
 		t.Run(testFn(`one:two,three`, []string{"one:two", "three"}))
+		t.Run(testFn(`"one\ntwo",""`, []string{"one\ntwo", ""}))
 	})
 
 	t.Run("Format", func(t *testing.T) {
@@ -40,4 +41,10 @@ func TestParseStrings(t *testing.T) {
 		t.Run(testFn([]string{"one", "two"}, `"one","two"`))
 		t.Run(testFn([]string{"one", `two "123"`}, `"one","two \"123\""`))
 	})
+	t.Run("RoundTrip", func(t *testing.T) {
+		values := []string{"", "one", `two "123"`, "three\n\t\\", "four:five", "\u00e9\x01"}
+		parsed, err := parser.ParseStrings(parser.FormatStrings(values))
+		require.NoError(t, err)
+		assert.Equal(t, values, parsed)
+	})
 }
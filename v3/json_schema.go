@@ -0,0 +1,74 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"encoding/json"
+	"io"
+)
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+var jsonSchemaPatterns = map[string]string{
+	"Boolean":  `^(1|true|yes|0|false|no)$`,
+	"Bytes":    `^([0-9a-fA-F]{2})*$`,
+	"Duration": `^[-+]?(([0-9]+(\.[0-9]*)?|\.[0-9]+)(ns|us|µs|ms|s|m|h))+$|^[-+]?0$`,
+	"Int":      `^[-+]?[0-9]+$`,
+}
+
+type jsonSchema struct {
+	Schema     string                        `json:"$schema"`
+	Type       string                        `json:"type"`
+	Properties map[string]jsonSchemaProperty `json:"properties"`
+	Required   []string                      `json:"required,omitempty"`
+}
+
+type jsonSchemaProperty struct {
+	Type        string   `json:"type"`
+	Description string   `json:"description,omitempty"`
+	Default     string   `json:"default,omitempty"`
+	Pattern     string   `json:"pattern,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+}
+
+// JSONSchema writes a JSON Schema document to the provided writer, that describes all registered fields. Each
+// field is represented by a string property of an object.
+func JSONSchema(w io.Writer) error {
+	return printJSONSchema(w, Fields())
+}
+
+func printJSONSchema(w io.Writer, fields []FieldInfo) error {
+	schema := jsonSchema{
+		Schema:     jsonSchemaDraft,
+		Type:       "object",
+		Properties: map[string]jsonSchemaProperty{},
+	}
+	for _, field := range fields {
+		schema.Properties[field.Name] = jsonSchemaProperty{
+			Type:        "string",
+			Description: describe(field),
+			Default:     field.DefaultValue,
+			Pattern:     jsonSchemaPatterns[field.Type],
+			Enum:        field.AllowedValues,
+		}
+		if field.Required {
+			schema.Required = append(schema.Required, field.Name)
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(schema)
+}
@@ -0,0 +1,111 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"expvar"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+const (
+	metricName     = "config_info"
+	hashLabelName  = "config_hash"
+	hashLabelBytes = 8
+)
+
+// MetricsHashKey defines the key of the HMAC, that is used to compute the label `config_hash` of the gauge
+// `config_info`. If no key is set, the hash is an SHA-256 over the non-sensitive fields only.
+var MetricsHashKey []byte
+
+// PublishExpvar publishes the values of all registered fields as a map under the provided name via the expvar
+// package. Values of sensitive fields are redacted. Like expvar.Publish, it panics if the name is already in use.
+func PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() any {
+		values := map[string]string{}
+		for _, state := range fieldStates() {
+			values[state.Name] = state.Value
+		}
+		return values
+	}))
+}
+
+// MetricsHandler returns an http.Handler that serves the registered fields as a gauge `config_info` in the
+// Prometheus text format (see the print format `prometheus`).
+func MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		if err := Print(w, "prometheus"); err != nil {
+			log.Printf("env metrics handler: %v", err)
+		}
+	})
+}
+
+func printPrometheus(w io.Writer, fields []FieldInfo) error {
+	ew := &errWriter{w: w}
+	var h hash.Hash
+	if len(MetricsHashKey) == 0 {
+		h = sha256.New()
+	} else {
+		h = hmac.New(sha256.New, MetricsHashKey)
+	}
+	names := map[string]bool{hashLabelName: true}
+	labels := []string{}
+	for _, field := range fields {
+		value := field.Value
+		if field.Sensitive {
+			if len(MetricsHashKey) > 0 {
+				fmt.Fprintf(h, "%s=%s\n", field.Name, value)
+			}
+			continue
+		}
+		fmt.Fprintf(h, "%s=%s\n", field.Name, value)
+		labels = append(labels, fmt.Sprintf("%s=\"%s\"", prometheusLabelName(field.Name, names), prometheusEscape(value)))
+	}
+	labels = append(labels, fmt.Sprintf("%s=\"%s\"", hashLabelName, hex.EncodeToString(h.Sum(nil)[:hashLabelBytes])))
+
+	fmt.Fprintf(ew, "# HELP %s Configuration of the environment fields. Sensitive fields are omitted.\n", metricName)
+	fmt.Fprintf(ew, "# TYPE %s gauge\n", metricName)
+	fmt.Fprintf(ew, "%s{%s} 1\n", metricName, strings.Join(labels, ","))
+	return ew.err
+}
+
+// prometheusLabelName returns a valid label name for the field name, that is not contained in the provided names
+// yet. Names starting with a digit or with the reserved prefix `__` are prefixed with `field_`, and names that are
+// already taken (e.g. by `config_hash`) get an underscore suffix.
+func prometheusLabelName(name string, names map[string]bool) string {
+	label := strings.ToLower(name)
+	if label == "" || (label[0] >= '0' && label[0] <= '9') || strings.HasPrefix(label, "__") {
+		label = "field_" + label
+	}
+	for names[label] {
+		label += "_"
+	}
+	names[label] = true
+	return label
+}
+
+func prometheusEscape(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return strings.ReplaceAll(value, "\n", `\n`)
+}
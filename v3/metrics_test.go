@@ -0,0 +1,89 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"bytes"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v3"
+)
+
+func TestMetrics(t *testing.T) {
+	env.ClearRegister()
+	env.Field("METRICS_NAME", "joe")
+	env.Field("METRICS_PASSWORD", "", env.Sensitive())
+	t.Setenv("METRICS_NAME", `jane "j"`)
+	t.Setenv("METRICS_PASSWORD", "secret")
+
+	t.Run("Expvar", func(t *testing.T) {
+		env.PublishExpvar("metrics_test")
+		assert.JSONEq(t, `{"METRICS_NAME":"jane \"j\"","METRICS_PASSWORD":"[redacted]"}`, expvar.Get("metrics_test").String())
+	})
+
+	t.Run("Prometheus", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		env.MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Regexp(t, `^# HELP config_info .+\n# TYPE config_info gauge\nconfig_info\{metrics_name="jane \\"j\\"",config_hash="[0-9a-f]{16}"\} 1\n$`, w.Body.String())
+		assert.NotContains(t, w.Body.String(), "secret")
+	})
+}
+
+func TestMetricsLabelNames(t *testing.T) {
+	env.ClearRegister()
+	env.Field("1ST", "one")
+	env.Field("CONFIG_HASH", "two")
+	env.Field("__META", "three")
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, env.Print(buffer, "prometheus"))
+	assert.Regexp(t, `\nconfig_info\{field_1st="one",config_hash_="two",field___meta="three",config_hash="[0-9a-f]{16}"\} 1\n$`, buffer.String())
+}
+
+func TestMetricsHash(t *testing.T) {
+	env.ClearRegister()
+	env.Field("METRICS_NAME", "joe")
+	env.Field("METRICS_PASSWORD", "", env.Sensitive())
+
+	hashRegexp := regexp.MustCompile(`config_hash="([0-9a-f]+)"`)
+	configHash := func(t *testing.T) string {
+		buffer := &bytes.Buffer{}
+		require.NoError(t, env.Print(buffer, "prometheus"))
+		return hashRegexp.FindStringSubmatch(buffer.String())[1]
+	}
+
+	t.Run("WithoutKey", func(t *testing.T) {
+		before := configHash(t)
+		t.Setenv("METRICS_PASSWORD", "secret")
+		assert.Equal(t, before, configHash(t))
+	})
+
+	t.Run("WithKey", func(t *testing.T) {
+		env.MetricsHashKey = []byte("key")
+		defer func() { env.MetricsHashKey = nil }()
+
+		before := configHash(t)
+		t.Setenv("METRICS_PASSWORD", "secret")
+		assert.NotEqual(t, before, configHash(t))
+	})
+}
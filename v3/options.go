@@ -14,6 +14,12 @@
 
 package env
 
+import (
+	"strings"
+
+	"github.com/simia-tech/env/v3/internal/location"
+)
+
 // Option defines an Option that can modify the options struct.
 type Option func(*options)
 
@@ -21,6 +27,25 @@ type options struct {
 	required      bool
 	allowedValues []string
 	description   string
+	group         string
+	tags          []string
+	sensitive     bool
+	expand        bool
+	aliases       []string
+	deprecation   string
+	flag          string
+	autoFlag      bool
+	location      string
+	errorHandler  func(error)
+	policy        *Policy
+}
+
+func init() {
+	location.Option = func(l string) interface{} {
+		return Option(func(o *options) {
+			o.location = l
+		})
+	}
 }
 
 func newOptions(opts []Option) options {
@@ -52,6 +77,98 @@ func Description(text string) Option {
 	}
 }
 
+// Group returns an Option that assigns the environment field to the named group.
+func Group(name string) Option {
+	return func(o *options) {
+		o.group = name
+	}
+}
+
+// Tags returns an Option that adds the provided tags to the environment field.
+func Tags(tags ...string) Option {
+	return func(o *options) {
+		o.tags = append(o.tags, tags...)
+	}
+}
+
+// Sensitive returns an Option that marks the environment field as sensitive. Sensitive values are redacted or
+// marked as sensitive in the output.
+func Sensitive() Option {
+	return func(o *options) {
+		o.sensitive = true
+	}
+}
+
+// Expand returns an Option that enables the expansion of references like `${NAME}` or `${NAME:-default}` in the
+// value of the environment field, or in the default value if the field is not set. References are resolved against
+// the registered fields, the environment and the sources. Undefined references and reference cycles are reported as
+// errors.
+func Expand() Option {
+	return func(o *options) {
+		o.expand = true
+	}
+}
+
+// Aliases returns an Option that defines previous names of the environment field. If the field itself is not set,
+// the value is read from the first alias that is set. Each use of an alias is reported once to the WarningHandler
+// and an alias that is set to a different value than the field is reported as error.
+func Aliases(names ...string) Option {
+	return func(o *options) {
+		o.aliases = append(o.aliases, names...)
+	}
+}
+
+// Deprecated returns an Option that marks the environment field as deprecated. The message should explain what to
+// use instead. If the field is set, the deprecation is reported once to the WarningHandler.
+func Deprecated(message string) Option {
+	return func(o *options) {
+		o.deprecation = message
+	}
+}
+
+// Flag returns an Option that binds the environment field to the named command-line flag. See BindFlags.
+func Flag(name string) Option {
+	return func(o *options) {
+		o.flag = name
+	}
+}
+
+// AutoFlag returns an Option that binds the environment field to a command-line flag, that is named after the
+// field in kebab-case, e.g. the field `DB_URL` is bound to the flag `db-url`. See BindFlags.
+func AutoFlag() Option {
+	return func(o *options) {
+		o.autoFlag = true
+	}
+}
+
+// OnError returns an Option that sets a handler for the errors of the environment field. It replaces the
+// ErrorHandler for this field.
+func OnError(handler func(error)) Option {
+	return func(o *options) {
+		o.errorHandler = handler
+	}
+}
+
+// FailurePolicy returns an Option that sets the policy for the errors of the environment field. It replaces the
+// ErrorPolicy for this field.
+func FailurePolicy(policy Policy) Option {
+	return func(o *options) {
+		o.policy = &policy
+	}
+}
+
+// flagName returns the name of the flag, that is bound to the field with the provided name, or an empty string if
+// the field is not bound to a flag.
+func (o *options) flagName(name string) string {
+	if o.flag != "" {
+		return o.flag
+	}
+	if o.autoFlag {
+		return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+	}
+	return ""
+}
+
 func (o *options) isAllowedValue(value string) bool {
 	if o == nil || o.allowedValues == nil {
 		return true
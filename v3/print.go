@@ -17,57 +17,136 @@ package env
 import (
 	"fmt"
 	"io"
+	"sort"
 )
 
-// Print prints the environment in the provided format.
-func Print(w io.Writer, format string) error {
-	p, ok := printer[format]
-	if !ok {
-		return fmt.Errorf("unknown format '%s'. known values are 'short-bash', 'long-bash', 'short-dockerfile' and 'long-dockerfile'", format)
+const requiredPlaceholder = "<required>"
+
+// Printer defines a format, in which the registered fields can be printed. Errors of the writer must be returned.
+type Printer interface {
+	Print(w io.Writer, fields []FieldInfo) error
+}
+
+// PrinterFunc implements the Printer interface for a function.
+type PrinterFunc func(io.Writer, []FieldInfo) error
+
+// Print calls f(w, fields).
+func (f PrinterFunc) Print(w io.Writer, fields []FieldInfo) error {
+	return f(w, fields)
+}
+
+var printers = map[string]Printer{
+	"short-bash":       PrinterFunc(printShortBash),
+	"long-bash":        PrinterFunc(printLongBash),
+	"short-dockerfile": PrinterFunc(printShortDockerfile),
+	"long-dockerfile":  PrinterFunc(printLongDockerfile),
+	"dotenv-example":   PrinterFunc(printDotenvExample),
+	"diff":             PrinterFunc(printDiff),
+	"missing":          PrinterFunc(printMissing),
+	"markdown":         PrinterFunc(printMarkdown),
+	"html":             PrinterFunc(printHTML),
+	"jsonschema":       PrinterFunc(printJSONSchema),
+	"terraform":        PrinterFunc(printTerraform),
+	"helm-values":      PrinterFunc(printHelmValues),
+	"helm-template":    PrinterFunc(printHelmTemplate),
+	"prometheus":       PrinterFunc(printPrometheus),
+}
+
+// RegisterPrinter registers the printer under the provided format name, so it can be used by Print and the
+// `-print-env-format` flag. A printer with the same name is replaced.
+func RegisterPrinter(name string, printer Printer) {
+	printers[name] = printer
+}
+
+// Printers returns the sorted names of all registered formats.
+func Printers() []string {
+	names := make([]string, 0, len(printers))
+	for name := range printers {
+		names = append(names, name)
 	}
-	p(w)
-	return nil
+	sort.Strings(names)
+	return names
 }
 
-var printer = map[string]func(io.Writer){
-	"short-bash":       printShortBash,
-	"long-bash":        printLongBash,
-	"short-dockerfile": printShortDockerfile,
-	"long-dockerfile":  printLongDockerfile,
+// Print prints the environment in the provided format. By default, all registered fields are printed, but the
+// selection and order can be modified by the provided options. Errors of the writer are returned.
+func Print(w io.Writer, format string, opts ...PrintOption) error {
+	printer, ok := printers[format]
+	if !ok {
+		return fmt.Errorf("unknown format '%s'. known values are %s", format, joinStringValues(Printers()))
+	}
+	return printer.Print(w, newPrintOptions(opts).selectFields(Fields()))
 }
 
-func printShortBash(w io.Writer) {
+func printShortBash(w io.Writer, fields []FieldInfo) error {
+	ew := &errWriter{w: w}
 	for _, field := range fields {
-		fmt.Fprintf(w, "%s=%q\n", field.Name(), field.GetRawOrDefault())
+		fmt.Fprintf(ew, "%s=%q\n", field.Name, field.Value)
 	}
+	return ew.err
 }
 
-func printLongBash(w io.Writer) {
+func printLongBash(w io.Writer, fields []FieldInfo) error {
+	ew := &errWriter{w: w}
 	for _, field := range fields {
-		fmt.Fprintf(w, "\n")
-		fmt.Fprintf(w, "# %s\n", field.Description())
-		fmt.Fprintf(w, "%s=%q\n", field.Name(), field.GetRawOrDefault())
+		fmt.Fprintf(ew, "\n")
+		fmt.Fprintf(ew, "# %s\n", describe(field))
+		fmt.Fprintf(ew, "%s=%q\n", field.Name, field.Value)
 	}
+	return ew.err
 }
 
-func printShortDockerfile(w io.Writer) {
-	index := 0
-	for _, field := range fields {
+func printShortDockerfile(w io.Writer, fields []FieldInfo) error {
+	ew := &errWriter{w: w}
+	for index, field := range fields {
 		if index == 0 {
-			fmt.Fprintf(w, "ENV ")
+			fmt.Fprintf(ew, "ENV ")
 		} else {
-			fmt.Fprintf(w, " \\\n    ")
+			fmt.Fprintf(ew, " \\\n    ")
 		}
-		fmt.Fprintf(w, "%s=%q", field.Name(), field.GetRawOrDefault())
-		index++
+		fmt.Fprintf(ew, "%s=%q", field.Name, field.Value)
+	}
+	fmt.Fprintln(ew)
+	return ew.err
+}
+
+func printLongDockerfile(w io.Writer, fields []FieldInfo) error {
+	ew := &errWriter{w: w}
+	for _, field := range fields {
+		fmt.Fprintln(ew)
+		fmt.Fprintf(ew, "# %s\n", describe(field))
+		fmt.Fprintf(ew, "ENV %s %q\n", field.Name, field.Value)
 	}
-	fmt.Fprintln(w)
+	return ew.err
 }
 
-func printLongDockerfile(w io.Writer) {
+// printDotenvExample writes a template for a .env file. Values are never printed, only the default values as
+// comments and a placeholder for each required field without default.
+func printDotenvExample(w io.Writer, fields []FieldInfo) error {
+	ew := &errWriter{w: w}
 	for _, field := range fields {
-		fmt.Fprintln(w)
-		fmt.Fprintf(w, "# %s\n", field.Description())
-		fmt.Fprintf(w, "ENV %s %q\n", field.Name(), field.GetRawOrDefault())
+		fmt.Fprintln(ew)
+		fmt.Fprintf(ew, "# %s\n", describe(field))
+		if field.DefaultValue == "" && field.Required {
+			fmt.Fprintf(ew, "%s=\"%s\"\n", field.Name, requiredPlaceholder)
+			continue
+		}
+		fmt.Fprintf(ew, "# %s=\"%s\"\n", field.Name, field.DefaultValue)
+	}
+	return ew.err
+}
+
+// errWriter wraps a writer and keeps the first error that occurred. All subsequent writes are skipped.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) Write(p []byte) (int, error) {
+	if ew.err != nil {
+		return 0, ew.err
 	}
+	n, err := ew.w.Write(p)
+	ew.err = err
+	return n, err
 }
@@ -0,0 +1,56 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+func printDiff(w io.Writer, fields []FieldInfo) error {
+	ew := &errWriter{w: w}
+	for _, field := range fields {
+		if field.Source == "default" {
+			continue
+		}
+		// Invalid values are shown with the raw value, since the resolved value falls back to the default.
+		if field.Err != nil {
+			raw := ""
+			var fieldErr *FieldError
+			if errors.As(field.Err, &fieldErr) {
+				raw = fieldErr.Raw
+			}
+			fmt.Fprintf(ew, "%s=\"%s\" # default: \"%s\", source: %s, error: %v\n", field.Name, raw, field.DefaultValue, field.Source, field.Err)
+			continue
+		}
+		if field.Value == field.DefaultValue {
+			continue
+		}
+		fmt.Fprintf(ew, "%s=\"%s\" # default: \"%s\", source: %s\n", field.Name, field.Value, field.DefaultValue, field.Source)
+	}
+	return ew.err
+}
+
+func printMissing(w io.Writer, fields []FieldInfo) error {
+	ew := &errWriter{w: w}
+	for _, field := range fields {
+		if !errors.Is(field.Err, ErrMissingValue) {
+			continue
+		}
+		fmt.Fprintf(ew, "%s # %s\n", field.Name, describe(field))
+	}
+	return ew.err
+}
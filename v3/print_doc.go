@@ -0,0 +1,165 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+)
+
+var docColumns = []string{"Name", "Type", "Default", "Required", "Allowed values", "Constraints", "Description", "Defined at"}
+
+// docDescription returns the description of the field for the documentation table. Deprecations are mentioned in
+// front of the description.
+func docDescription(field FieldInfo) string {
+	description := field.Description
+	if field.Aliases != nil {
+		description = strings.TrimSpace(fmt.Sprintf("Deprecated names are %s. %s", joinStringValues(field.Aliases), description))
+	}
+	if field.Deprecation != "" {
+		description = strings.TrimSpace(fmt.Sprintf("Deprecated: %s. %s", field.Deprecation, description))
+	}
+	return description
+}
+
+// docGroups splits the fields into groups of the same `Group` option. The fields without a group come first, the
+// other groups are sorted by name. The order of the fields within a group is kept.
+func docGroups(fields []FieldInfo) ([]string, map[string][]FieldInfo) {
+	names := []string{}
+	groups := map[string][]FieldInfo{}
+	for _, field := range fields {
+		if _, ok := groups[field.Group]; !ok {
+			names = append(names, field.Group)
+		}
+		groups[field.Group] = append(groups[field.Group], field)
+	}
+	sort.Strings(names)
+	return names, groups
+}
+
+func printMarkdown(w io.Writer, fields []FieldInfo) error {
+	ew := &errWriter{w: w}
+	names, groups := docGroups(fields)
+	for index, name := range names {
+		if index > 0 {
+			fmt.Fprintln(ew)
+		}
+		if name != "" {
+			fmt.Fprintf(ew, "### %s\n\n", markdownEscape(name))
+		}
+		printMarkdownTable(ew, groups[name])
+	}
+	return ew.err
+}
+
+func printMarkdownTable(w io.Writer, fields []FieldInfo) {
+	fmt.Fprintf(w, "| %s |\n", strings.Join(docColumns, " | "))
+	fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", len(docColumns)))
+	for _, field := range fields {
+		values := []string{}
+		for _, value := range field.AllowedValues {
+			if value != "" {
+				values = append(values, markdownCode(value))
+			}
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join([]string{
+			markdownCode(field.Name),
+			markdownEscape(field.Type),
+			markdownCode(field.DefaultValue),
+			yesNo(field.Required),
+			strings.Join(values, ", "),
+			markdownEscape(strings.Join(field.Constraints, " ")),
+			markdownEscape(docDescription(field)),
+			markdownEscape(field.Location),
+		}, " | "))
+	}
+}
+
+func printHTML(w io.Writer, fields []FieldInfo) error {
+	ew := &errWriter{w: w}
+	fmt.Fprintln(ew, "<table>")
+	fmt.Fprintln(ew, "  <thead>")
+	fmt.Fprint(ew, "    <tr>")
+	for _, column := range docColumns {
+		fmt.Fprintf(ew, "<th>%s</th>", html.EscapeString(column))
+	}
+	fmt.Fprintln(ew, "</tr>")
+	fmt.Fprintln(ew, "  </thead>")
+	fmt.Fprintln(ew, "  <tbody>")
+	names, groups := docGroups(fields)
+	for _, name := range names {
+		if name != "" {
+			fmt.Fprintf(ew, "    <tr><th colspan=\"%d\">%s</th></tr>\n", len(docColumns), html.EscapeString(name))
+		}
+		printHTMLRows(ew, groups[name])
+	}
+	fmt.Fprintln(ew, "  </tbody>")
+	fmt.Fprintln(ew, "</table>")
+	return ew.err
+}
+
+func printHTMLRows(w io.Writer, fields []FieldInfo) {
+	for _, field := range fields {
+		values := []string{}
+		for _, value := range field.AllowedValues {
+			if value != "" {
+				values = append(values, htmlCode(value))
+			}
+		}
+		fmt.Fprint(w, "    <tr>")
+		for _, cell := range []string{
+			htmlCode(field.Name),
+			html.EscapeString(field.Type),
+			htmlCode(field.DefaultValue),
+			yesNo(field.Required),
+			strings.Join(values, ", "),
+			html.EscapeString(strings.Join(field.Constraints, " ")),
+			html.EscapeString(docDescription(field)),
+			html.EscapeString(field.Location),
+		} {
+			fmt.Fprintf(w, "<td>%s</td>", cell)
+		}
+		fmt.Fprintln(w, "</tr>")
+	}
+}
+
+func markdownEscape(text string) string {
+	text = strings.ReplaceAll(text, "|", "\\|")
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
+func markdownCode(text string) string {
+	if text == "" {
+		return ""
+	}
+	return "`" + markdownEscape(text) + "`"
+}
+
+func htmlCode(text string) string {
+	if text == "" {
+		return ""
+	}
+	return "<code>" + html.EscapeString(text) + "</code>"
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
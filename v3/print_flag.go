@@ -22,7 +22,7 @@ import (
 
 // ParseFlags tests if the print-flag was given at the program start and prints the registered
 // environment fields with thier values to stdout using the specified format. Afterwards, the program exits
-// with return code 2.
+// with return code 2. The flags of fields with the option Flag or AutoFlag are bound as well (see BindFlags).
 func ParseFlags() {
 	WithFlags(func() {
 		flag.Parse()
@@ -30,8 +30,9 @@ func ParseFlags() {
 }
 
 func WithFlags(fn func()) {
+	BindFlags(flag.CommandLine)
 	printEnvFlag := flag.Bool("print-env", false, "print the environment with the current values")
-	printEnvFormatFlag := flag.String("print-env-format", "short-bash", "print the environment in the given format. format can be 'short-bash', 'long-bash', 'short-dockerfile' and 'long-dockerfile'")
+	printEnvFormatFlag := flag.String("print-env-format", "short-bash", "print the environment in the given format. format can be "+joinStringValues(Printers()))
 
 	fn()
 
@@ -0,0 +1,136 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"sort"
+	"strings"
+)
+
+// PrintOption defines an option that modifies the selection and order of the printed fields.
+type PrintOption func(*printOptions)
+
+type printOptions struct {
+	prefix           string
+	group            *string
+	tag              string
+	sortByName       bool
+	sortByGroup      bool
+	excludeSensitive bool
+	requiredOnly     bool
+}
+
+func newPrintOptions(opts []PrintOption) *printOptions {
+	o := &printOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// PrintPrefix returns a PrintOption that selects the fields with a name starting with the provided prefix.
+func PrintPrefix(prefix string) PrintOption {
+	return func(o *printOptions) {
+		o.prefix = prefix
+	}
+}
+
+// PrintGroup returns a PrintOption that selects the fields of the named group.
+func PrintGroup(name string) PrintOption {
+	return func(o *printOptions) {
+		o.group = &name
+	}
+}
+
+// PrintTag returns a PrintOption that selects the fields with the provided tag.
+func PrintTag(tag string) PrintOption {
+	return func(o *printOptions) {
+		o.tag = tag
+	}
+}
+
+// PrintSortByName returns a PrintOption that sorts the fields by name. By default, the fields are printed in the
+// order of their registration.
+func PrintSortByName() PrintOption {
+	return func(o *printOptions) {
+		o.sortByName = true
+	}
+}
+
+// PrintSortByGroup returns a PrintOption that sorts the fields by group. Within a group, the fields keep their
+// order.
+func PrintSortByGroup() PrintOption {
+	return func(o *printOptions) {
+		o.sortByGroup = true
+	}
+}
+
+// PrintWithoutSensitive returns a PrintOption that excludes sensitive fields.
+func PrintWithoutSensitive() PrintOption {
+	return func(o *printOptions) {
+		o.excludeSensitive = true
+	}
+}
+
+// PrintRequiredOnly returns a PrintOption that selects only required fields.
+func PrintRequiredOnly() PrintOption {
+	return func(o *printOptions) {
+		o.requiredOnly = true
+	}
+}
+
+func (o *printOptions) selectFields(infos []FieldInfo) []FieldInfo {
+	result := []FieldInfo{}
+	for _, info := range infos {
+		if o.isSelected(info) {
+			result = append(result, info)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if o.sortByGroup && result[i].Group != result[j].Group {
+			return result[i].Group < result[j].Group
+		}
+		return o.sortByName && result[i].Name < result[j].Name
+	})
+	return result
+}
+
+func (o *printOptions) isSelected(info FieldInfo) bool {
+	if !strings.HasPrefix(info.Name, o.prefix) {
+		return false
+	}
+	if o.group != nil && info.Group != *o.group {
+		return false
+	}
+	if o.tag != "" && !hasString(info.Tags, o.tag) {
+		return false
+	}
+	if o.excludeSensitive && info.Sensitive {
+		return false
+	}
+	if o.requiredOnly && !info.Required {
+		return false
+	}
+	return true
+}
+
+func hasString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
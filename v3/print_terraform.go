@@ -0,0 +1,86 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+func printTerraform(w io.Writer, fields []FieldInfo) error {
+	ew := &errWriter{w: w}
+	for index, field := range fields {
+		name := strings.ToLower(field.Name)
+
+		if index > 0 {
+			fmt.Fprintln(ew)
+		}
+		fmt.Fprintf(ew, "variable %s {\n", hclString(name))
+		fmt.Fprintf(ew, "  description = %s\n", hclString(describe(field)))
+		fmt.Fprintf(ew, "  type        = string\n")
+		if defaultValue := field.DefaultValue; defaultValue != "" || !field.Required {
+			fmt.Fprintf(ew, "  default     = %s\n", hclString(defaultValue))
+		}
+		if field.Sensitive {
+			fmt.Fprintf(ew, "  sensitive   = true\n")
+		}
+		if field.AllowedValues != nil {
+			values := make([]string, len(field.AllowedValues))
+			for index, value := range field.AllowedValues {
+				values[index] = hclString(value)
+			}
+			fmt.Fprintf(ew, "\n")
+			fmt.Fprintf(ew, "  validation {\n")
+			fmt.Fprintf(ew, "    condition     = contains([%s], var.%s)\n", strings.Join(values, ", "), name)
+			fmt.Fprintf(ew, "    error_message = %s\n", hclString(fmt.Sprintf("Allowed values are %s.", joinStringValues(field.AllowedValues))))
+			fmt.Fprintf(ew, "  }\n")
+		}
+		fmt.Fprintf(ew, "}\n")
+	}
+	return ew.err
+}
+
+func hclString(value string) string {
+	s := strings.Builder{}
+	s.WriteRune('"')
+	for index, c := range value {
+		switch c {
+		case '"':
+			s.WriteString(`\"`)
+		case '\\':
+			s.WriteString(`\\`)
+		case '\n':
+			s.WriteString(`\n`)
+		case '\r':
+			s.WriteString(`\r`)
+		case '\t':
+			s.WriteString(`\t`)
+		case '$', '%':
+			s.WriteRune(c)
+			if strings.HasPrefix(value[index+1:], "{") {
+				s.WriteRune(c)
+			}
+		default:
+			if c < ' ' {
+				fmt.Fprintf(&s, `\u%04x`, c)
+			} else {
+				s.WriteRune(c)
+			}
+		}
+	}
+	s.WriteRune('"')
+	return s.String()
+}
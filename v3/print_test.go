@@ -16,6 +16,10 @@ package env_test
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -42,4 +46,201 @@ func TestPrint(t *testing.T) {
 	t.Run("LongBash", testFn("long-bash", `^\n# String field. Required field. Allowed values are 'one', 'two' and 'three'. The default value is 'default'. Defined at \S+print_test\.go:\d+\.\nTEST_ONE="default"\n\n# String field. The default value is 'default'. Defined at \S+print_test\.go:\d+\.\nTEST_TWO="default"\n$`))
 	t.Run("ShortDockerfile", testFn("short-dockerfile", `^ENV TEST_ONE="default" \\\n    TEST_TWO="default"\n$`))
 	t.Run("LongDockerfile", testFn("long-dockerfile", `^\n# String field. Required field. Allowed values are 'one', 'two' and 'three'. The default value is 'default'. Defined at \S+print_test\.go:\d+.\nENV TEST_ONE "default"\n\n# String field. The default value is 'default'. Defined at \S+print_test\.go:\d+.\nENV TEST_TWO "default"\n$`))
+	t.Run("Markdown", testFn("markdown", "^\\| Name \\| Type \\| Default \\| Required \\| Allowed values \\| Constraints \\| Description \\| Defined at \\|\n(\\| --- )+\\|\n\\| `TEST_ONE` \\| String \\| `default` \\| yes \\| `one`, `two`, `three` \\|  \\|  \\| \\S+print_test\\.go:\\d+ \\|\n\\| `TEST_TWO` \\| String \\| `default` \\| no \\|  \\|  \\|  \\| \\S+print_test\\.go:\\d+ \\|\n$"))
+	t.Run("HTML", testFn("html", `^<table>\n  <thead>\n    <tr>(<th>[^<]+</th>){8}</tr>\n  </thead>\n  <tbody>\n    <tr><td><code>TEST_ONE</code></td><td>String</td><td><code>default</code></td><td>yes</td><td><code>one</code>, <code>two</code>, <code>three</code></td><td></td><td></td><td>\S+print_test\.go:\d+</td></tr>\n    <tr><td><code>TEST_TWO</code></td>.+</tr>\n  </tbody>\n</table>\n$`))
+}
+
+func TestPrintDocGroups(t *testing.T) {
+	env.ClearRegister()
+	cert := env.Field("TEST_CERT", "", env.Group("tls"))
+	key := env.Field("TEST_KEY", "", env.Group("tls"), env.Description("The key."))
+	env.Field("TEST_NAME", "joe")
+	env.RequireTogether(cert, key)
+
+	testFn := func(format string, expectOutputPattern string) func(*testing.T) {
+		return func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			require.NoError(t, env.Print(buffer, format))
+			assert.Regexp(t, expectOutputPattern, buffer.String())
+		}
+	}
+
+	t.Run("Markdown", testFn("markdown", "^\\| Name .+\n.+\n\\| `TEST_NAME` .+\n\n### tls\n\n\\| Name .+\n.+\n"+
+		"\\| `TEST_CERT` \\| String \\|  \\| no \\|  \\| Must be set together with 'TEST_KEY'. \\|  \\| .+\n"+
+		"\\| `TEST_KEY` \\| String \\|  \\| no \\|  \\| Must be set together with 'TEST_CERT'. \\| The key. \\| .+\n$"))
+	t.Run("HTML", testFn("html", `<tbody>\n    <tr><td><code>TEST_NAME</code></td>.+\n    <tr><th colspan="8">tls</th></tr>\n`+
+		`    <tr><td><code>TEST_CERT</code></td>.+<td>Must be set together with &#39;TEST_KEY&#39;.</td><td></td>.+\n`+
+		`    <tr><td><code>TEST_KEY</code></td>.+\n  </tbody>`))
+}
+
+func TestPrintDotenvExample(t *testing.T) {
+	env.ClearRegister()
+	env.Field("TEST_ONE", "default", env.Required())
+	env.Field("TEST_TWO", "", env.Required())
+	env.Field("TEST_THREE", "default")
+
+	t.Setenv("TEST_THREE", "secret")
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, env.Print(buffer, "dotenv-example"))
+	assert.Regexp(t, `^\n# String field. Required field. The default value is 'default'. Defined at \S+print_test\.go:\d+\.\n# TEST_ONE="default"\n\n# String field. Required field. The default value is ''. Defined at \S+print_test\.go:\d+\.\nTEST_TWO="<required>"\n\n# String field. The default value is 'default'. Defined at \S+print_test\.go:\d+\.\n# TEST_THREE="default"\n$`, buffer.String())
+}
+
+func TestPrintDiff(t *testing.T) {
+	env.ClearRegister()
+	env.Field("TEST_ONE", "default")
+	env.Field("TEST_TWO", "default")
+	env.Field("TEST_THREE", "default", env.Aliases("TEST_OLD_THREE"))
+	env.Field("TEST_FOUR", "", env.Required())
+	env.Field("TEST_PORT", 8080)
+	wh := env.WarningHandler
+	env.WarningHandler = env.NullErrorHandler
+	defer func() { env.WarningHandler = wh }()
+
+	t.Setenv("TEST_ONE", "changed")
+	t.Setenv("TEST_TWO", "default")
+	t.Setenv("TEST_OLD_THREE", "renamed")
+	t.Setenv("TEST_PORT", "abc")
+
+	testFn := func(format string, expectOutputPattern string) func(*testing.T) {
+		return func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			require.NoError(t, env.Print(buffer, format))
+			assert.Regexp(t, expectOutputPattern, buffer.String())
+		}
+	}
+
+	t.Run("Diff", testFn("diff", `^TEST_ONE="changed" # default: "default", source: environment\n`+
+		`TEST_THREE="renamed" # default: "default", source: environment\n`+
+		`TEST_PORT="abc" # default: "8080", source: environment, error: field \[TEST_PORT\]: .+\n$`))
+	t.Run("Missing", testFn("missing", `^TEST_FOUR # String field. Required field. The default value is ''. Defined at \S+print_test\.go:\d+\.\n$`))
+}
+
+func TestRegisterPrinter(t *testing.T) {
+	env.ClearRegister()
+	env.Field("TEST_ONE", "one")
+	env.Field("TEST_TWO", 2)
+
+	env.RegisterPrinter("names", env.PrinterFunc(func(w io.Writer, fields []env.FieldInfo) error {
+		for _, field := range fields {
+			if _, err := fmt.Fprintf(w, "%s:%s\n", field.Name, field.Type); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	assert.Contains(t, env.Printers(), "names")
+
+	buffer := &bytes.Buffer{}
+	require.NoError(t, env.Print(buffer, "names"))
+	assert.Equal(t, "TEST_ONE:String\nTEST_TWO:Int\n", buffer.String())
+
+	err := env.Print(buffer, "unknown")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "'names'")
+}
+
+func TestPrintOptions(t *testing.T) {
+	env.ClearRegister()
+	env.Field("APP_ONE", "default", env.Group("b"))
+	env.Field("APP_TWO", "default", env.Group("a"), env.Tags("network"), env.Required())
+	env.Field("APP_THREE", "default", env.Group("a"), env.Sensitive())
+	env.Field("OTHER_FOUR", "default", env.Tags("network"))
+
+	testFn := func(expectNames []string, opts ...env.PrintOption) func(*testing.T) {
+		return func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			require.NoError(t, env.Print(buffer, "short-bash", opts...))
+
+			names := []string{}
+			for _, line := range strings.Fields(buffer.String()) {
+				names = append(names, strings.SplitN(line, "=", 2)[0])
+			}
+			assert.Equal(t, expectNames, names)
+		}
+	}
+
+	t.Run("Default", testFn([]string{"APP_ONE", "APP_TWO", "APP_THREE", "OTHER_FOUR"}))
+	t.Run("Prefix", testFn([]string{"APP_ONE", "APP_TWO", "APP_THREE"}, env.PrintPrefix("APP_")))
+	t.Run("Group", testFn([]string{"APP_TWO", "APP_THREE"}, env.PrintGroup("a")))
+	t.Run("NoGroup", testFn([]string{"OTHER_FOUR"}, env.PrintGroup("")))
+	t.Run("Tag", testFn([]string{"APP_TWO", "OTHER_FOUR"}, env.PrintTag("network")))
+	t.Run("SortByName", testFn([]string{"APP_ONE", "APP_THREE", "APP_TWO", "OTHER_FOUR"}, env.PrintSortByName()))
+	t.Run("SortByGroup", testFn([]string{"OTHER_FOUR", "APP_TWO", "APP_THREE", "APP_ONE"}, env.PrintSortByGroup()))
+	t.Run("SortByGroupAndName", testFn([]string{"OTHER_FOUR", "APP_THREE", "APP_TWO", "APP_ONE"}, env.PrintSortByGroup(), env.PrintSortByName()))
+	t.Run("WithoutSensitive", testFn([]string{"APP_ONE", "APP_TWO", "OTHER_FOUR"}, env.PrintWithoutSensitive()))
+	t.Run("RequiredOnly", testFn([]string{"APP_TWO"}, env.PrintRequiredOnly()))
+}
+
+func TestPrintWriterError(t *testing.T) {
+	env.ClearRegister()
+	env.Field("TEST_ONE", "default")
+
+	for _, format := range env.Printers() {
+		t.Run(format, func(t *testing.T) {
+			err := env.Print(failingWriter{}, format)
+			if format == "diff" || format == "missing" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, errWrite)
+			}
+		})
+	}
+}
+
+var errWrite = errors.New("write failed")
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errWrite
+}
+
+func TestPrintInfrastructure(t *testing.T) {
+	env.ClearRegister()
+	env.Field("TEST_ONE", "one", env.AllowedValues("one", "two"), env.Description("Mode."))
+	env.Field("TEST_TWO", "", env.Required(), env.Sensitive(), env.Description("Password with ${braces}."))
+
+	testFn := func(format string, expectOutput string) func(*testing.T) {
+		return func(t *testing.T) {
+			buffer := &bytes.Buffer{}
+			require.NoError(t, env.Print(buffer, format))
+			assert.Equal(t, expectOutput, buffer.String())
+		}
+	}
+
+	t.Run("Terraform", testFn("terraform", `variable "test_one" {
+  description = "Mode."
+  type        = string
+  default     = "one"
+
+  validation {
+    condition     = contains(["one", "two"], var.test_one)
+    error_message = "Allowed values are 'one' and 'two'."
+  }
+}
+
+variable "test_two" {
+  description = "Password with $${braces}."
+  type        = string
+  sensitive   = true
+}
+`))
+	t.Run("HelmValues", testFn("helm-values", `env:
+  # Mode.
+  TEST_ONE: "one"
+  # Password with ${braces}.
+  TEST_TWO: ""
+`))
+	t.Run("HelmTemplate", testFn("helm-template", `env:
+  - name: TEST_ONE
+    value: {{ .Values.env.TEST_ONE | quote }}
+  - name: TEST_TWO
+    value: {{ .Values.env.TEST_TWO | quote }}
+`))
+	t.Run("UnknownFormat", func(t *testing.T) {
+		err := env.Print(&bytes.Buffer{}, "unknown")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "'terraform'")
+	})
 }
@@ -1,18 +1,115 @@
 package env
 
-var fields = []generalField{}
+import (
+	"fmt"
+	"reflect"
+)
+
+var (
+	fields = []generalField{}
+	// duplicates holds the fields, that have been declared with an already registered name. They are not printed,
+	// but their subscribers are notified on reload.
+	duplicates = []generalField{}
+)
 
 type generalField interface {
 	Name() string
 	Description() string
 	GetRaw() (string, error)
 	GetRawOrDefault() string
+	getRaw(lookupFunc) (string, error)
+	info() FieldInfo
+	defaultRaw() string
+	typeLabel() string
+	fieldLocation() string
+	fieldOptions() *options
+	text(lookupFunc) (string, error)
+	value(lookupFunc) (any, error)
+	reload(lookupFunc, lookupFunc) (func(), error)
 }
 
+// ClearRegister removes all registered fields, constraints, all sources that have been added via AddSource and the
+// values of bound flags, deactivates the active snapshot and forgets the warnings that have been reported. It's
+// meant for tests, that need to start with an empty registry.
 func ClearRegister() {
 	fields = []generalField{}
+	duplicates = []generalField{}
+	constraints = []constraint{}
+	clearSources()
+	clearFlags()
+	clearWarnings()
+	Unload()
+}
+
+// findField returns the registered field with the provided name, or nil if no such field exists.
+func findField(name string) generalField {
+	for _, field := range fields {
+		if field.Name() == name {
+			return field
+		}
+	}
+	return nil
 }
 
+// registeredFields returns all registered fields including the duplicates.
+func registeredFields() []generalField {
+	return append(append([]generalField{}, fields...), duplicates...)
+}
+
+// DuplicatePolicy defines how a field is handled, if a field with the same name is already registered.
+type DuplicatePolicy int
+
+// Possible duplicate policies.
+const (
+	// DuplicateFirstWins keeps the first registered field. If the definitions differ, the WarningHandler is called.
+	DuplicateFirstWins DuplicatePolicy = iota
+	// DuplicateIdentical keeps the first registered field, but panics if the definitions differ.
+	DuplicateIdentical
+	// DuplicatePanic panics on every field with a name that is already registered.
+	DuplicatePanic
+)
+
+// DuplicateFieldPolicy defines the policy for fields with a name that is already registered. By default,
+// DuplicateFirstWins is set.
+var DuplicateFieldPolicy = DuplicateFirstWins
+
 func registerField(field generalField) {
+	for _, registered := range fields {
+		if registered.Name() == field.Name() {
+			handleDuplicate(registered, field)
+			duplicates = append(duplicates, field)
+			return
+		}
+	}
 	fields = append(fields, field)
 }
+
+func handleDuplicate(registered, duplicate generalField) {
+	identical := sameDefinition(registered, duplicate)
+	if identical && DuplicateFieldPolicy != DuplicatePanic {
+		return
+	}
+	err := fmt.Errorf("field [%s] defined at %s: %w: already defined at %s",
+		duplicate.Name(), duplicate.fieldLocation(), ErrDuplicateField, registered.fieldLocation())
+	if !identical {
+		err = fmt.Errorf("%w with a different definition", err)
+	}
+	if DuplicateFieldPolicy == DuplicateFirstWins {
+		WarningHandler(err)
+		return
+	}
+	panic(err)
+}
+
+// sameDefinition returns true if both fields have the same type, default value and options. The location and the
+// error handling of the fields are not compared.
+func sameDefinition(a, b generalField) bool {
+	if a.typeLabel() != b.typeLabel() || a.defaultRaw() != b.defaultRaw() {
+		return false
+	}
+	oa, ob := *a.fieldOptions(), *b.fieldOptions()
+	oa.location, ob.location = "", ""
+	oa.errorHandler, ob.errorHandler = nil, nil
+	oa.policy, ob.policy = nil, nil
+	return reflect.DeepEqual(oa, ob)
+}
@@ -0,0 +1,93 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v3"
+)
+
+func TestDuplicateFields(t *testing.T) {
+	warnings := []error{}
+	wh := env.WarningHandler
+	env.WarningHandler = func(err error) {
+		warnings = append(warnings, err)
+	}
+	dp := env.DuplicateFieldPolicy
+	defer func() {
+		env.WarningHandler = wh
+		env.DuplicateFieldPolicy = dp
+	}()
+
+	testFn := func(policy env.DuplicatePolicy, second func(), expectPanic bool, expectErrPattern string) func(*testing.T) {
+		return func(t *testing.T) {
+			env.ClearRegister()
+			env.DuplicateFieldPolicy = policy
+			warnings = warnings[:0]
+
+			env.Field("DUPLICATE_FIELD", "default", env.Description("Test field."))
+
+			if expectPanic {
+				defer func() {
+					err, ok := recover().(error)
+					require.True(t, ok)
+					assert.True(t, errors.Is(err, env.ErrDuplicateField))
+					assert.Regexp(t, expectErrPattern, err.Error())
+				}()
+				second()
+				return
+			}
+
+			second()
+			if expectErrPattern == "" {
+				assert.Empty(t, warnings)
+			} else {
+				require.Len(t, warnings, 1)
+				assert.True(t, errors.Is(warnings[0], env.ErrDuplicateField))
+				assert.Regexp(t, expectErrPattern, warnings[0].Error())
+			}
+
+			buffer := &bytes.Buffer{}
+			require.NoError(t, env.Print(buffer, "short-bash"))
+			assert.Equal(t, "DUPLICATE_FIELD=\"default\"\n", buffer.String())
+		}
+	}
+
+	identical := func() {
+		env.Field("DUPLICATE_FIELD", "default", env.Description("Test field."))
+	}
+	otherDefault := func() {
+		env.Field("DUPLICATE_FIELD", "other", env.Description("Test field."))
+	}
+	otherType := func() {
+		env.Field("DUPLICATE_FIELD", 0, env.Description("Test field."))
+	}
+
+	differentPattern := fmt.Sprintf(`^field \[DUPLICATE_FIELD\] defined at %[1]s: duplicate field: already defined at %[1]s with a different definition$`, `\S+register_test\.go:\d+`)
+	samePattern := fmt.Sprintf(`^field \[DUPLICATE_FIELD\] defined at %[1]s: duplicate field: already defined at %[1]s$`, `\S+register_test\.go:\d+`)
+
+	t.Run("FirstWinsIdentical", testFn(env.DuplicateFirstWins, identical, false, ""))
+	t.Run("FirstWinsOtherDefault", testFn(env.DuplicateFirstWins, otherDefault, false, differentPattern))
+	t.Run("IdenticalIdentical", testFn(env.DuplicateIdentical, identical, false, ""))
+	t.Run("IdenticalOtherType", testFn(env.DuplicateIdentical, otherType, true, differentPattern))
+	t.Run("PanicIdentical", testFn(env.DuplicatePanic, identical, true, samePattern))
+}
@@ -0,0 +1,97 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+
+package env
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogAttrs returns an attribute for each registered field with the field's value. Values of sensitive fields are
+// redacted.
+func LogAttrs() []slog.Attr {
+	lookup := currentLookup()
+	attrs := []slog.Attr{}
+	for _, field := range fields {
+		attrs = append(attrs, slog.Attr{Key: field.Name(), Value: logValue(field, lookup)})
+	}
+	return attrs
+}
+
+// LogConfig emits a single record to the provided logger, that contains the values of all registered fields in
+// the group `env`.
+func LogConfig(logger *slog.Logger) {
+	logger.LogAttrs(context.Background(), slog.LevelInfo, "configuration", slog.Attr{Key: "env", Value: slog.GroupValue(LogAttrs()...)})
+}
+
+// LogFields emits a record for each registered field to the provided logger, that contains the field's name,
+// value, default value and source. If the field holds an invalid value, the default value is logged as value and
+// the record contains the error.
+func LogFields(logger *slog.Logger) {
+	lookup := currentLookup()
+	for _, field := range fields {
+		value, err := logResolve(field, lookup)
+		defaultValue := field.defaultRaw()
+		if field.fieldOptions().sensitive {
+			defaultValue = redact(defaultValue)
+		}
+		attrs := []slog.Attr{
+			slog.String("name", field.Name()),
+			slog.String("value", value),
+			slog.String("default", defaultValue),
+			slog.String("source", sourceIn(lookup, field.Name())),
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+		logger.LogAttrs(context.Background(), slog.LevelInfo, "configuration field", attrs...)
+	}
+}
+
+// SlogErrorHandler returns an error handler, that emits each error as a record with the provided level to the
+// logger.
+func SlogErrorHandler(logger *slog.Logger, level slog.Level) func(error) {
+	return func(err error) {
+		logger.LogAttrs(context.Background(), level, err.Error())
+	}
+}
+
+// LogValue implements slog.LogValuer.
+func (f *F[T]) LogValue() slog.Value {
+	return logValue(f, currentLookup())
+}
+
+// logValue returns the field's value. If the field holds an invalid value, a group of the default value and the
+// error is returned.
+func logValue(field generalField, lookup lookupFunc) slog.Value {
+	value, err := logResolve(field, lookup)
+	if err != nil {
+		return slog.GroupValue(slog.String("value", value), slog.String("error", err.Error()))
+	}
+	return slog.StringValue(value)
+}
+
+func logResolve(field generalField, lookup lookupFunc) (string, error) {
+	value, err := field.text(lookup)
+	if field.fieldOptions().sensitive {
+		value = redact(value)
+		if err != nil {
+			err = redactError(err)
+		}
+	}
+	return value, err
+}
@@ -0,0 +1,76 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.21
+
+package env_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/simia-tech/env/v3"
+)
+
+func TestSlog(t *testing.T) {
+	env.ClearRegister()
+	name := env.Field("SLOG_NAME", "joe")
+	password := env.Field("SLOG_PASSWORD", "", env.Sensitive())
+	port := env.Field("SLOG_PORT", 8080)
+	t.Setenv("SLOG_PASSWORD", "secret")
+	t.Setenv("SLOG_PORT", "abc")
+
+	newLogger := func(buffer *bytes.Buffer) *slog.Logger {
+		return slog.New(slog.NewTextHandler(buffer, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey && len(groups) == 0 {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}))
+	}
+
+	t.Run("LogValuer", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		newLogger(buffer).Info("start", "name", name, "password", password, "port", port)
+		assert.Equal(t, "level=INFO msg=start name=joe password=[redacted] port.value=8080 port.error=\"field [SLOG_PORT]: value [abc]: parse int: invalid value\"\n", buffer.String())
+	})
+
+	t.Run("LogConfig", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		env.LogConfig(newLogger(buffer))
+		assert.Contains(t, buffer.String(), "level=INFO msg=configuration env.SLOG_NAME=joe env.SLOG_PASSWORD=[redacted] env.SLOG_PORT.value=8080")
+	})
+
+	t.Run("LogFields", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		env.LogFields(newLogger(buffer))
+		assert.Equal(t, ""+
+			"level=INFO msg=\"configuration field\" name=SLOG_NAME value=joe default=joe source=default\n"+
+			"level=INFO msg=\"configuration field\" name=SLOG_PASSWORD value=[redacted] default=\"\" source=environment\n"+
+			"level=INFO msg=\"configuration field\" name=SLOG_PORT value=8080 default=8080 source=environment error=\"field [SLOG_PORT]: value [abc]: parse int: invalid value\"\n",
+			buffer.String())
+	})
+
+	t.Run("ErrorHandler", func(t *testing.T) {
+		buffer := &bytes.Buffer{}
+		env.SlogErrorHandler(newLogger(buffer), slog.LevelWarn)(errors.New("deprecated"))
+		assert.Equal(t, "level=WARN msg=deprecated\n", buffer.String())
+	})
+}
@@ -0,0 +1,105 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import "sync/atomic"
+
+// Snapshot holds the values of all registered fields, that have been resolved and parsed at the same time. A
+// snapshot is immutable. The slices and maps returned by Value are shared and must not be modified.
+type Snapshot struct {
+	lookups map[string]lookupResult
+	values  map[generalField]any
+}
+
+type lookupResult struct {
+	value  string
+	source string
+	ok     bool
+}
+
+var activeSnapshot atomic.Value
+
+// Load resolves, validates and parses all registered fields, checks the registered constraints and activates the
+// resulting snapshot. As long as a
+// snapshot is active, the fields return the values of the snapshot instead of reading the environment and the
+// sources, and Reload replaces the snapshot atomically. If a field is invalid or a constraint is violated, the
+// error is returned and the active snapshot is kept.
+func Load() (*Snapshot, error) {
+	reloadMutex.Lock()
+	defer reloadMutex.Unlock()
+
+	s, err := newSnapshot(liveLookup())
+	if err != nil {
+		return nil, err
+	}
+	activeSnapshot.Store(s)
+	return s, nil
+}
+
+// Unload deactivates the active snapshot, so the fields are read from the environment and the sources again.
+func Unload() {
+	activeSnapshot.Store((*Snapshot)(nil))
+}
+
+// Value returns the value of the field in the snapshot. If the field has been registered after the snapshot has
+// been loaded, the field's current value is returned.
+func Value[T FieldType](s *Snapshot, field *F[T]) T {
+	if value, ok := s.values[field]; ok {
+		return value.(T)
+	}
+	return field.GetOrDefault()
+}
+
+func loadedSnapshot() *Snapshot {
+	s, _ := activeSnapshot.Load().(*Snapshot)
+	return s
+}
+
+// newSnapshot resolves all registered fields via the provided lookup. Each name, that is looked up, is recorded, so
+// the snapshot's lookup returns the same values later on.
+func newSnapshot(lookup lookupFunc) (*Snapshot, error) {
+	s := &Snapshot{
+		lookups: map[string]lookupResult{},
+		values:  map[generalField]any{},
+	}
+	record := func(name string) (string, string, bool) {
+		if result, ok := s.lookups[name]; ok {
+			return result.value, result.source, result.ok
+		}
+		value, source, ok := lookup(name)
+		s.lookups[name] = lookupResult{value: value, source: source, ok: ok}
+		return value, source, ok
+	}
+	for _, field := range registeredFields() {
+		value, err := field.value(record)
+		if err != nil {
+			return nil, err
+		}
+		s.values[field] = value
+	}
+	if err := checkConstraints(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// lookup returns the recorded value of the name. Names, that haven't been looked up during the creation of the
+// snapshot, are looked up in the environment and the sources.
+func (s *Snapshot) lookup(name string) (string, string, bool) {
+	if result, ok := s.lookups[name]; ok {
+		return result.value, result.source, result.ok
+	}
+	return liveLookup()(name)
+}
@@ -0,0 +1,92 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v3"
+)
+
+func TestLoad(t *testing.T) {
+	env.ClearRegister()
+	defer env.Unload()
+
+	field := env.Field("SNAPSHOT_FIELD", 1)
+	names := env.Field("SNAPSHOT_NAMES", []string(nil))
+
+	t.Setenv("SNAPSHOT_FIELD", "2")
+	t.Setenv("SNAPSHOT_NAMES", "a,b")
+	snapshot, err := env.Load()
+	require.NoError(t, err)
+	assert.Equal(t, 2, env.Value(snapshot, field))
+	assert.Equal(t, []string{"a", "b"}, env.Value(snapshot, names))
+
+	t.Setenv("SNAPSHOT_FIELD", "3")
+	assert.Equal(t, 2, field.GetOrDefault())
+	assert.Equal(t, "2", field.MustGetRaw())
+	info, _ := env.Lookup("SNAPSHOT_FIELD")
+	assert.Equal(t, "2", info.Value)
+
+	t.Setenv("SNAPSHOT_FIELD", "invalid")
+	_, err = env.Load()
+	assert.ErrorIs(t, err, env.ErrInvalidValue)
+	assert.Equal(t, 2, field.GetOrDefault())
+
+	late := env.Field("SNAPSHOT_LATE", "default")
+	t.Setenv("SNAPSHOT_LATE", "late")
+	assert.Equal(t, "late", env.Value(snapshot, late))
+
+	env.Unload()
+	t.Setenv("SNAPSHOT_FIELD", "4")
+	assert.Equal(t, 4, field.GetOrDefault())
+}
+
+func TestLoadAndReload(t *testing.T) {
+	env.ClearRegister()
+	defer env.Unload()
+
+	path := filepath.Join(t.TempDir(), "app.env")
+	require.NoError(t, os.WriteFile(path, []byte("SNAPSHOT_PORT=80\n"), 0o600))
+	source, err := env.NewFileSource(path)
+	require.NoError(t, err)
+	env.AddSource(source)
+	field := env.Field("SNAPSHOT_PORT", 8080)
+
+	loaded, err := env.Load()
+	require.NoError(t, err)
+	changes := [][2]int{}
+	field.OnChange(func(old, new int) {
+		changes = append(changes, [2]int{old, new})
+	})
+
+	require.NoError(t, os.WriteFile(path, []byte("SNAPSHOT_PORT=81\n"), 0o600))
+	assert.Equal(t, 80, field.GetOrDefault())
+	require.NoError(t, env.Reload())
+	assert.Equal(t, [][2]int{{80, 81}}, changes)
+	assert.Equal(t, 81, field.GetOrDefault())
+	assert.Equal(t, 80, env.Value(loaded, field))
+
+	t.Setenv("SNAPSHOT_PORT", "82")
+	assert.Equal(t, 81, field.GetOrDefault())
+	require.NoError(t, env.Reload())
+	assert.Equal(t, [][2]int{{80, 81}, {81, 82}}, changes)
+	assert.Equal(t, 82, field.GetOrDefault())
+}
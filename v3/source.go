@@ -0,0 +1,203 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Source defines a source of values, that is consulted if a field is not set in the environment.
+type Source interface {
+	Lookup(name string) (string, bool)
+}
+
+// lookupFunc returns the value of the provided name together with a description of its source.
+type lookupFunc func(name string) (value string, source string, ok bool)
+
+var (
+	sourcesMutex sync.RWMutex
+	sources      = []Source{}
+)
+
+// AddSource adds the provided source. Sources are consulted in the order they have been added, after the
+// environment.
+func AddSource(source Source) {
+	sourcesMutex.Lock()
+	sources = append(sources, source)
+	sourcesMutex.Unlock()
+}
+
+func clearSources() {
+	sourcesMutex.Lock()
+	sources = []Source{}
+	sourcesMutex.Unlock()
+}
+
+// currentLookup returns the lookup of the active snapshot, or the lookup of the environment and the sources if no
+// snapshot is active.
+func currentLookup() lookupFunc {
+	if s := loadedSnapshot(); s != nil {
+		return s.lookup
+	}
+	return liveLookup()
+}
+
+func liveLookup() lookupFunc {
+	sourcesMutex.RLock()
+	current := sources
+	sourcesMutex.RUnlock()
+	return lookupIn(current)
+}
+
+func lookupIn(sources []Source) lookupFunc {
+	return func(name string) (string, string, bool) {
+		if value, ok := lookupFlag(name); ok {
+			return value, "flag", true
+		}
+		if value, ok := os.LookupEnv(name); ok {
+			return value, "environment", true
+		}
+		for _, source := range sources {
+			if value, ok := source.Lookup(name); ok {
+				return value, sourceName(source), true
+			}
+		}
+		return "", "default", false
+	}
+}
+
+// sourceIn returns the description of the source, that provides the value of the name in the lookup.
+func sourceIn(lookup lookupFunc, name string) string {
+	_, source, _ := lookup(name)
+	return source
+}
+
+// sourceName returns the description of the source. Sources can describe themselves by implementing fmt.Stringer.
+func sourceName(source Source) string {
+	if fileSource, ok := source.(*FileSource); ok {
+		return "file " + fileSource.path
+	}
+	if stringer, ok := source.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return "source"
+}
+
+// FileSource implements a Source that reads its values from a file or a directory. A file holds one `NAME=VALUE`
+// pair per line, where empty lines and lines starting with `#` are skipped and double-quoted values are unquoted.
+// In a directory, e.g. a mounted ConfigMap or secret, each file holds the value of the field named like the file.
+// The values are read when the source is created and on each Reload.
+type FileSource struct {
+	path   string
+	mutex  sync.RWMutex
+	values map[string]string
+}
+
+// NewFileSource returns a FileSource for the provided path.
+func NewFileSource(path string) (*FileSource, error) {
+	s := &FileSource{path: path}
+	values, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	s.values = values
+	return s, nil
+}
+
+// Lookup returns the value of the field with the provided name.
+func (s *FileSource) Lookup(name string) (string, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	value, ok := s.values[name]
+	return value, ok
+}
+
+// withValues returns a copy of the source with the provided values.
+func (s *FileSource) withValues(values map[string]string) *FileSource {
+	return &FileSource{path: s.path, values: values}
+}
+
+func (s *FileSource) setValues(values map[string]string) {
+	s.mutex.Lock()
+	s.values = values
+	s.mutex.Unlock()
+}
+
+func (s *FileSource) read() (map[string]string, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("file source: %w", err)
+	}
+	if info.IsDir() {
+		return readDirectory(s.path)
+	}
+	return readFile(s.path)
+}
+
+func readDirectory(path string) (map[string]string, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("file source: %w", err)
+	}
+	values := map[string]string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || !nameRegexp.MatchString(name) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(path, name))
+		if err != nil {
+			return nil, fmt.Errorf("file source: %w", err)
+		}
+		values[name] = strings.TrimSpace(string(content))
+	}
+	return values, nil
+}
+
+func readFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("file source: %w", err)
+	}
+	values := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("file source: %s:%d: missing '='", path, number)
+		}
+		name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+		if strings.HasPrefix(value, `"`) {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("file source: %s:%d: %w", path, number, err)
+			}
+			value = unquoted
+		}
+		values[name] = value
+	}
+	return values, scanner.Err()
+}
@@ -0,0 +1,172 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/simia-tech/env/v3"
+)
+
+func TestFileSource(t *testing.T) {
+	t.Run("File", func(t *testing.T) {
+		env.ClearRegister()
+		path := filepath.Join(t.TempDir(), "app.env")
+		require.NoError(t, os.WriteFile(path, []byte("# comment\nSOURCE_NAME=joe\nSOURCE_GREETING=\"hello\\nworld\"\n"), 0o600))
+		source, err := env.NewFileSource(path)
+		require.NoError(t, err)
+		env.AddSource(source)
+
+		assert.Equal(t, "joe", env.Field("SOURCE_NAME", "default").GetOrDefault())
+		assert.Equal(t, "hello\nworld", env.Field("SOURCE_GREETING", "default").GetOrDefault())
+		assert.Equal(t, "default", env.Field("SOURCE_MISSING", "default").GetOrDefault())
+	})
+
+	t.Run("Directory", func(t *testing.T) {
+		env.ClearRegister()
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "SOURCE_PASSWORD"), []byte("secret\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte("ignored"), 0o600))
+		source, err := env.NewFileSource(dir)
+		require.NoError(t, err)
+		env.AddSource(source)
+
+		assert.Equal(t, "secret", env.Field("SOURCE_PASSWORD", "").GetOrDefault())
+	})
+
+	t.Run("EnvironmentHasPrecedence", func(t *testing.T) {
+		env.ClearRegister()
+		path := filepath.Join(t.TempDir(), "app.env")
+		require.NoError(t, os.WriteFile(path, []byte("SOURCE_NAME=joe\n"), 0o600))
+		source, err := env.NewFileSource(path)
+		require.NoError(t, err)
+		env.AddSource(source)
+		t.Setenv("SOURCE_NAME", "jane")
+
+		assert.Equal(t, "jane", env.Field("SOURCE_NAME", "default").GetOrDefault())
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "app.env")
+		require.NoError(t, os.WriteFile(path, []byte("SOURCE_NAME\n"), 0o600))
+		_, err := env.NewFileSource(path)
+		assert.EqualError(t, err, "file source: "+path+":1: missing '='")
+	})
+}
+
+func TestReload(t *testing.T) {
+	setup := func(t *testing.T, content string) (string, *env.F[int]) {
+		env.ClearRegister()
+		path := filepath.Join(t.TempDir(), "app.env")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		source, err := env.NewFileSource(path)
+		require.NoError(t, err)
+		env.AddSource(source)
+		return path, env.Field("RELOAD_PORT", 8080)
+	}
+
+	t.Run("Change", func(t *testing.T) {
+		path, field := setup(t, "RELOAD_PORT=80\n")
+		changes := [][2]int{}
+		field.OnChange(func(old, new int) {
+			changes = append(changes, [2]int{old, new})
+			assert.Equal(t, new, field.GetOrDefault())
+		})
+
+		require.NoError(t, env.Reload())
+		assert.Empty(t, changes)
+
+		require.NoError(t, os.WriteFile(path, []byte("RELOAD_PORT=81\n"), 0o600))
+		require.NoError(t, env.Reload())
+		assert.Equal(t, [][2]int{{80, 81}}, changes)
+		assert.Equal(t, 81, field.GetOrDefault())
+	})
+
+	t.Run("InvalidValueIsNotPublished", func(t *testing.T) {
+		path, field := setup(t, "RELOAD_PORT=80\n")
+		changes := 0
+		field.OnChange(func(int, int) { changes++ })
+
+		require.NoError(t, os.WriteFile(path, []byte("RELOAD_PORT=abc\n"), 0o600))
+		err := env.Reload()
+		assert.ErrorIs(t, err, env.ErrInvalidValue)
+		assert.Equal(t, 0, changes)
+		assert.Equal(t, 80, field.GetOrDefault())
+	})
+
+	t.Run("ErrorNamesStagedSource", func(t *testing.T) {
+		path, field := setup(t, "")
+
+		require.NoError(t, os.WriteFile(path, []byte("RELOAD_PORT=abc\n"), 0o600))
+		err := env.Reload()
+		fieldErr := &env.FieldError{}
+		require.ErrorAs(t, err, &fieldErr)
+		assert.Equal(t, "file "+path, fieldErr.Source)
+		assert.Equal(t, 8080, field.GetOrDefault())
+	})
+
+	t.Run("Watch", func(t *testing.T) {
+		path, field := setup(t, "RELOAD_PORT=80\n")
+		changed := make(chan int, 1)
+		field.OnChange(func(_, new int) { changed <- new })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go env.Watch(ctx, 10*time.Millisecond, func(err error) { t.Error(err) })
+
+		require.NoError(t, os.WriteFile(path, []byte("RELOAD_PORT=82\n"), 0o600))
+		select {
+		case value := <-changed:
+			assert.Equal(t, 82, value)
+		case <-time.After(time.Second):
+			t.Fatal("no change has been published")
+		}
+	})
+}
+
+func TestWithSource(t *testing.T) {
+	env.ClearRegister()
+	field := env.Field("CONTEXT_PORT", 8080)
+	t.Setenv("CONTEXT_PORT", "80")
+
+	ctx := env.WithSource(context.Background(), mapSource{"CONTEXT_PORT": "81"})
+	assert.Equal(t, 81, field.GetOrDefaultContext(ctx))
+	nested := env.WithSource(ctx, mapSource{"CONTEXT_PORT": "abc"})
+	_, err := field.GetContext(nested)
+	fieldErr := &env.FieldError{}
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "map", fieldErr.Source)
+	assert.Equal(t, 81, field.GetOrDefaultContext(ctx))
+	assert.Equal(t, 80, field.GetOrDefault())
+	assert.Equal(t, 80, field.GetOrDefaultContext(context.Background()))
+}
+
+type mapSource map[string]string
+
+func (s mapSource) Lookup(name string) (string, bool) {
+	value, ok := s[name]
+	return value, ok
+}
+
+func (s mapSource) String() string {
+	return "map"
+}
@@ -5,6 +5,12 @@ func joinStringValues(values []string) string {
 }
 
 func joinStrings(values []string, sepRune, sepWord string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	if len(values) == 1 {
+		return "'" + values[0] + "'"
+	}
 	text := ""
 	for index := 0; index < len(values)-1; index++ {
 		if index > 0 {
@@ -0,0 +1,123 @@
+// Copyright 2018 Philipp Brüll <pb@simia.tech>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// 		http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+// Reload re-reads all file sources and notifies the subscribers of each field, whose value has changed. Changed
+// values are validated before they are published. If a changed value is invalid, no value is published and the
+// error is returned.
+func Reload() error {
+	notifications, err := reload()
+	if err != nil {
+		return err
+	}
+	for _, notify := range notifications {
+		notify()
+	}
+	return nil
+}
+
+// reloadMutex serializes the reloads and the loading of snapshots. The sources are only read-locked while they are
+// copied, so the fields can be read during a reload.
+var reloadMutex sync.Mutex
+
+func reload() ([]func(), error) {
+	reloadMutex.Lock()
+	defer reloadMutex.Unlock()
+
+	sourcesMutex.RLock()
+	current := sources
+	sourcesMutex.RUnlock()
+
+	staged := make([]Source, len(current))
+	stagedValues := map[*FileSource]map[string]string{}
+	for index, source := range current {
+		staged[index] = source
+		if fs, ok := source.(*FileSource); ok {
+			values, err := fs.read()
+			if err != nil {
+				return nil, err
+			}
+			staged[index] = fs.withValues(values)
+			stagedValues[fs] = values
+		}
+	}
+
+	publishedLookup, stagedLookup := currentLookup(), lookupIn(staged)
+	stagedSnapshot := (*Snapshot)(nil)
+	if loadedSnapshot() != nil {
+		s, err := newSnapshot(stagedLookup)
+		if err != nil {
+			return nil, fmt.Errorf("reload: %w", err)
+		}
+		stagedSnapshot, stagedLookup = s, s.lookup
+	}
+
+	notifications := []func(){}
+	for _, field := range registeredFields() {
+		notify, err := field.reload(publishedLookup, stagedLookup)
+		if err != nil {
+			return nil, fmt.Errorf("reload: %w", err)
+		}
+		if notify != nil {
+			notifications = append(notifications, notify)
+		}
+	}
+
+	for fs, values := range stagedValues {
+		fs.setValues(values)
+	}
+	if stagedSnapshot != nil {
+		activeSnapshot.Store(stagedSnapshot)
+	}
+	return notifications, nil
+}
+
+// Watch calls Reload every interval and each time one of the provided signals (e.g. `syscall.SIGHUP`) is
+// received, until the context is done. An interval of zero disables polling. Errors of Reload are passed to errFn.
+func Watch(ctx context.Context, interval time.Duration, errFn func(error), signals ...os.Signal) {
+	tick := (<-chan time.Time)(nil)
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	received := make(chan os.Signal, 1)
+	if len(signals) > 0 {
+		signal.Notify(received, signals...)
+		defer signal.Stop(received)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+		case <-received:
+		}
+		if err := Reload(); err != nil && errFn != nil {
+			errFn(err)
+		}
+	}
+}